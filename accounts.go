@@ -2,13 +2,54 @@ package mastodon
 
 import (
 	"fmt"
+	"net/http"
 	"net/url"
 	"strconv"
 )
 
 // Accounts implements methods under /accounts.
 type Accounts struct {
-	api *API
+	api   *API
+	Cache *RelationshipCache // Caches relationships if not nil
+}
+
+// relationshipsBatchSize is the number of relationships requested at once.
+const relationshipsBatchSize = 40
+
+// Registration holds the params to register a new account.
+type Registration struct {
+	Username   string // The desired username
+	Email      string // The email address to be used for login
+	Password   string // The password to be used for login
+	Agreement  bool   // Whether the user agrees to the local rules and terms
+	Locale     string // The language of the confirmation email, e.g. "en"
+	Reason     string // Text shown to moderators if registrations require approval
+	InviteCode string // An invite code, if registrations require an invite
+}
+
+func (r Registration) values() url.Values {
+	v := url.Values{
+		"username":  {r.Username},
+		"email":     {r.Email},
+		"password":  {r.Password},
+		"agreement": {strconv.FormatBool(r.Agreement)},
+		"locale":    {r.Locale},
+	}
+	if r.Reason != "" {
+		v.Set("reason", r.Reason)
+	}
+	if r.InviteCode != "" {
+		v.Set("invite_code", r.InviteCode)
+	}
+	return v
+}
+
+// Register creates a new account and returns a token to authenticate it. The
+// API has to be authenticated with an app token, see App.AppToken. A
+// *ResponseError with Details describes invalid fields.
+func (accounts Accounts) Register(r Registration) (Token, error) {
+	t := Token{}
+	return t, accounts.api.Post("accounts", r.values(), &t)
 }
 
 // VerifyCredentials returns the authenticated user's account.
@@ -17,6 +58,120 @@ func (accounts Accounts) VerifyCredentials() (Account, error) {
 	return acc, accounts.api.Get("accounts/verify_credentials", nil, &acc)
 }
 
+// Credentials holds the params to update the authenticated user's profile.
+// Nil and empty values are left unchanged.
+type Credentials struct {
+	DisplayName  *string // The name to display in the profile
+	Note         *string // The biography of the user
+	Avatar       *File   // The avatar image
+	Header       *File   // The header image
+	Locked       *bool   // Whether follows have to be approved manually
+	Bot          *bool   // Whether the account performs automated actions
+	Discoverable *bool   // Whether the account is shown in the profile directory
+	Indexable    *bool   // Whether public posts may be searched by anyone
+	Fields       []Field // Up to four profile metadata fields, replacing the current ones
+	Privacy      string  // The default visibility of new statuses
+	Sensitive    *bool   // Whether new statuses are marked sensitive by default
+	Language     string  // The default language of new statuses
+}
+
+func (c Credentials) values() url.Values {
+	v := url.Values{}
+	if c.DisplayName != nil {
+		v.Set("display_name", *c.DisplayName)
+	}
+	if c.Note != nil {
+		v.Set("note", *c.Note)
+	}
+	bools := map[string]*bool{
+		"locked":            c.Locked,
+		"bot":               c.Bot,
+		"discoverable":      c.Discoverable,
+		"indexable":         c.Indexable,
+		"source[sensitive]": c.Sensitive,
+	}
+	for key, b := range bools {
+		if b != nil {
+			v.Set(key, strconv.FormatBool(*b))
+		}
+	}
+	for i, f := range c.Fields {
+		v.Set(fmt.Sprintf("fields_attributes[%d][name]", i), f.Name)
+		v.Set(fmt.Sprintf("fields_attributes[%d][value]", i), f.Value)
+	}
+	if c.Privacy != "" {
+		v.Set("source[privacy]", c.Privacy)
+	}
+	if c.Language != "" {
+		v.Set("source[language]", c.Language)
+	}
+	return v
+}
+
+// body encodes the params as a JSON body, for updates without images.
+func (c Credentials) body() map[string]interface{} {
+	body := map[string]interface{}{}
+	if c.DisplayName != nil {
+		body["display_name"] = *c.DisplayName
+	}
+	if c.Note != nil {
+		body["note"] = *c.Note
+	}
+	bools := map[string]*bool{
+		"locked":       c.Locked,
+		"bot":          c.Bot,
+		"discoverable": c.Discoverable,
+		"indexable":    c.Indexable,
+	}
+	for key, b := range bools {
+		if b != nil {
+			body[key] = *b
+		}
+	}
+	if len(c.Fields) > 0 {
+		fields := []map[string]string{}
+		for _, f := range c.Fields {
+			fields = append(fields, map[string]string{"name": f.Name, "value": f.Value})
+		}
+		body["fields_attributes"] = fields
+	}
+	source := map[string]interface{}{}
+	if c.Privacy != "" {
+		source["privacy"] = c.Privacy
+	}
+	if c.Sensitive != nil {
+		source["sensitive"] = *c.Sensitive
+	}
+	if c.Language != "" {
+		source["language"] = c.Language
+	}
+	if len(source) > 0 {
+		body["source"] = source
+	}
+	return body
+}
+
+// UpdateCredentials updates the authenticated user's profile and returns the
+// updated account. The params are sent as JSON unless images are attached.
+func (accounts Accounts) UpdateCredentials(c Credentials) (CredentialAccount, error) {
+	acc := CredentialAccount{}
+	if len(c.Fields) > 4 {
+		return acc, fmt.Errorf("could not update credentials: %d fields given, at most 4 allowed", len(c.Fields))
+	}
+	if c.Avatar == nil && c.Header == nil {
+		return acc, accounts.api.PatchJSON("accounts/update_credentials", c.body(), &acc)
+	}
+	files := map[string]File{}
+	if c.Avatar != nil {
+		files["avatar"] = *c.Avatar
+	}
+	if c.Header != nil {
+		files["header"] = *c.Header
+	}
+	err := accounts.api.Upload(http.MethodPatch, "accounts/update_credentials", c.values(), files, &acc)
+	return acc, err
+}
+
 // Get returns an account.
 func (accounts Accounts) Get(id string) (Account, error) {
 	acc := Account{}
@@ -24,6 +179,22 @@ func (accounts Accounts) Get(id string) (Account, error) {
 	return acc, accounts.api.Get(end, nil, &acc)
 }
 
+// Lookup returns an account by its handle, e.g. "user" for local or
+// "user@domain" for remote accounts. Unlike Search it does not match fuzzily
+// or resolve unknown remote accounts.
+func (accounts Accounts) Lookup(acct string) (Account, error) {
+	acc := Account{}
+	v := url.Values{"acct": {acct}}
+	return acc, accounts.api.Get("accounts/lookup", v, &acc)
+}
+
+// FeaturedTags returns the hashtags featured on an account's profile.
+func (accounts Accounts) FeaturedTags(id string) ([]FeaturedTag, error) {
+	t := []FeaturedTag{}
+	end := fmt.Sprintf("accounts/%s/featured_tags", id)
+	return t, accounts.api.Get(end, nil, &t)
+}
+
 // Followers returns an slice of following accounts.
 func (accounts Accounts) Followers(id string) ([]Account, error) {
 	accs := []Account{}
@@ -38,67 +209,171 @@ func (accounts Accounts) Following(id string) ([]Account, error) {
 	return accs, accounts.api.Get(end, nil, &accs)
 }
 
-// Statuses returns an slice of statuses. Accepted params are:
-// only_media: Only return statuses that have media attachments
-// exclude_replies: Skip statuses that reply to other statuses
-func (accounts Accounts) Statuses(id string, params url.Values) ([]Status, error) {
+// AccountStatusesOptions holds the params to filter the statuses of an
+// account. Empty values use the server's defaults.
+type AccountStatusesOptions struct {
+	Pagination
+	OnlyMedia      bool   // Only return statuses that have media attachments
+	ExcludeReplies bool   // Skip statuses that reply to other statuses
+	ExcludeReblogs bool   // Skip reblogs of other statuses
+	Pinned         bool   // Only return pinned statuses
+	Tagged         string // Only return statuses using this hashtag
+}
+
+func (opts AccountStatusesOptions) values() url.Values {
+	v := url.Values{}
+	opts.Pagination.setValues(v)
+	bools := map[string]bool{
+		"only_media":      opts.OnlyMedia,
+		"exclude_replies": opts.ExcludeReplies,
+		"exclude_reblogs": opts.ExcludeReblogs,
+		"pinned":          opts.Pinned,
+	}
+	for key, b := range bools {
+		if b {
+			v.Set(key, "true")
+		}
+	}
+	if opts.Tagged != "" {
+		v.Set("tagged", opts.Tagged)
+	}
+	return v
+}
+
+// Statuses returns an slice of statuses. opts may be nil.
+func (accounts Accounts) Statuses(id string, opts *AccountStatusesOptions) ([]Status, error) {
 	end := fmt.Sprintf("accounts/%s/statuses", id)
 	statuses := []Status{}
-	return statuses, accounts.api.Get(end, params, &statuses)
+	v := url.Values{}
+	if opts != nil {
+		v = opts.values()
+	}
+	return statuses, accounts.api.Get(end, v, &statuses)
 }
 
-// Follow an user.
-func (accounts Accounts) Follow(id string) (Account, error) {
-	acc := Account{}
-	end := fmt.Sprintf("accounts/%s/follow", id)
-	return acc, accounts.api.Get(end, nil, &acc)
+// FollowOptions holds the params to follow an account. Nil values use the
+// server's defaults.
+type FollowOptions struct {
+	Reblogs   *bool    // Whether to show reblogs of the account in the home timeline
+	Notify    *bool    // Whether to be notified when the account posts a status
+	Languages []string // Only show statuses in these ISO 639-1 languages, all if empty
 }
 
-// Unfollow an account
-func (accounts Accounts) Unfollow(id string) (Account, error) {
-	acc := Account{}
-	end := fmt.Sprintf("accounts/%s/unfollow", id)
-	return acc, accounts.api.Post(end, nil, &acc)
+func (opts FollowOptions) values() url.Values {
+	v := url.Values{}
+	if opts.Reblogs != nil {
+		v.Set("reblogs", strconv.FormatBool(*opts.Reblogs))
+	}
+	if opts.Notify != nil {
+		v.Set("notify", strconv.FormatBool(*opts.Notify))
+	}
+	for _, lang := range opts.Languages {
+		v.Add("languages[]", lang)
+	}
+	return v
 }
 
-// Block an account
-func (accounts Accounts) Block(id string) (Account, error) {
-	acc := Account{}
-	end := fmt.Sprintf("accounts/%s/block", id)
-	return acc, accounts.api.Post(end, nil, &acc)
+// Follow an user and returns the updated relationship. Following an account
+// again updates the options. opts may be nil.
+func (accounts Accounts) Follow(id string, opts *FollowOptions) (Relationship, error) {
+	v := url.Values{}
+	if opts != nil {
+		v = opts.values()
+	}
+	return accounts.changeRelationship(id, "follow", v)
 }
 
-// Unblock an account.
-func (accounts Accounts) Unblock(id string) (Account, error) {
-	acc := Account{}
-	end := fmt.Sprintf("accounts/%s/unblock", id)
-	return acc, accounts.api.Post(end, nil, &acc)
+// Unfollow an account and returns the updated relationship.
+func (accounts Accounts) Unfollow(id string) (Relationship, error) {
+	return accounts.changeRelationship(id, "unfollow", nil)
 }
 
-// Mute an account.
-func (accounts Accounts) Mute(id string) (Account, error) {
-	acc := Account{}
-	end := fmt.Sprintf("accounts/%s/mute", id)
-	return acc, accounts.api.Post(end, nil, &acc)
+// Block an account and returns the updated relationship.
+func (accounts Accounts) Block(id string) (Relationship, error) {
+	return accounts.changeRelationship(id, "block", nil)
 }
 
-// Unmute an user.
-func (accounts Accounts) Unmute(id string) (Account, error) {
-	acc := Account{}
-	end := fmt.Sprintf("accounts/%s/unmute", id)
-	return acc, accounts.api.Post(end, nil, &acc)
+// Unblock an account and returns the updated relationship.
+func (accounts Accounts) Unblock(id string) (Relationship, error) {
+	return accounts.changeRelationship(id, "unblock", nil)
+}
+
+// Mute an account and returns the updated relationship.
+func (accounts Accounts) Mute(id string) (Relationship, error) {
+	return accounts.changeRelationship(id, "mute", nil)
+}
+
+// Unmute an user and returns the updated relationship.
+func (accounts Accounts) Unmute(id string) (Relationship, error) {
+	return accounts.changeRelationship(id, "unmute", nil)
+}
+
+// changeRelationship posts an action like "follow" or "block" and drops the
+// cached relationship to the account.
+func (accounts Accounts) changeRelationship(id, action string, v url.Values) (Relationship, error) {
+	rel := Relationship{}
+	end := fmt.Sprintf("accounts/%s/%s", id, action)
+	if err := accounts.api.Post(end, v, &rel); err != nil {
+		return rel, err
+	}
+	accounts.Cache.Invalidate(id)
+	return rel, nil
 }
 
 // Relationships returns an slice of Relationships of the current user to a
 // list of given accounts.
+//
+// Deprecated: Use RelationshipsByID instead.
 func (accounts Accounts) Relationships(ids ...int) ([]Relationship, error) {
 	idss := []string{}
 	for _, id := range ids {
 		idss = append(idss, strconv.Itoa(id))
 	}
+	return accounts.RelationshipsByID(idss, false)
+}
+
+// RelationshipsByID returns an slice of Relationships of the current user to
+// a list of given accounts, in the order of ids. Large lists are requested in
+// batches. Suspended accounts are skipped unless withSuspended is set.
+func (accounts Accounts) RelationshipsByID(ids []string, withSuspended bool) ([]Relationship, error) {
+	found := map[string]Relationship{}
+	missing := []string{}
+	for _, id := range ids {
+		if rel, ok := accounts.Cache.Get(id, withSuspended); ok {
+			found[id] = rel
+			continue
+		}
+		missing = append(missing, id)
+	}
+
+	var err error
+	for len(missing) > 0 {
+		n := relationshipsBatchSize
+		if n > len(missing) {
+			n = len(missing)
+		}
+		batch := []Relationship{}
+		v := url.Values{
+			"id[]":           missing[:n],
+			"with_suspended": {strconv.FormatBool(withSuspended)},
+		}
+		if err = accounts.api.Get("accounts/relationships", v, &batch); err != nil {
+			break
+		}
+		accounts.Cache.Set(withSuspended, batch...)
+		for _, rel := range batch {
+			found[rel.ID] = rel
+		}
+		missing = missing[n:]
+	}
+
 	rels := []Relationship{}
-	v := url.Values{"id": idss}
-	return rels, accounts.api.Get("accounts/relationships", v, &rels)
+	for _, id := range ids {
+		if rel, ok := found[id]; ok {
+			rels = append(rels, rel)
+		}
+	}
+	return rels, err
 }
 
 // Search returns an slice of matching Accounts. Will lookup an account
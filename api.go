@@ -1,13 +1,17 @@
 package mastodon
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
+	"mime"
+	"mime/multipart"
 	"net/http"
+	"net/textproto"
 	"net/url"
+	"path/filepath"
 )
 
 // API contains necessary informations to work with Mastodons API.
@@ -17,23 +21,142 @@ type API struct {
 	AccessToken string
 }
 
+// File is a file to be uploaded in a multipart request.
+type File struct {
+	Name        string    // The filename, e.g. "avatar.png"
+	ContentType string    // Detected from the name or content if empty
+	Reader      io.Reader // The content of the file
+}
+
 // Do executes an API request. The method is a HTTP method, e.g. GET or POST.
+// Values are sent as query for GET and DELETE and as form otherwise.
 func (api API) Do(method string, endpoint string, values url.Values) (io.ReadCloser, error) {
-	client := &http.Client{}
-	r := bytes.NewBufferString(values.Encode())
-	req, err := http.NewRequest(method, api.Base+api.Prefix+endpoint, r)
+	res, err := api.do(method, endpoint, values)
 	if err != nil {
-		return nil, fmt.Errorf("could not create request to %s: %v", endpoint, err)
+		return nil, err
+	}
+	return res.Body, nil
+}
+
+// do executes an API request like Do and returns the response, e.g. to read
+// its headers.
+func (api API) do(method string, endpoint string, values url.Values) (*http.Response, error) {
+	var body io.Reader
+	withBody := method != http.MethodGet && method != http.MethodDelete && len(values) > 0
+	if withBody {
+		body = bytes.NewBufferString(values.Encode())
+	}
+	req, err := api.newRequest(method, endpoint, body)
+	if err != nil {
+		return nil, err
 	}
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", api.AccessToken))
 
-	switch method {
-	case http.MethodGet:
+	if withBody {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	} else {
 		req.URL.RawQuery = values.Encode()
-	case http.MethodPost:
-		req.Form = values
 	}
 
+	return api.send(req, endpoint)
+}
+
+// DoJSON executes an API request with body encoded as JSON. The method is a
+// HTTP method, e.g. POST or PUT.
+func (api API) DoJSON(method string, endpoint string, body interface{}) (io.ReadCloser, error) {
+	return api.doJSON(method, endpoint, body, nil)
+}
+
+// doJSON executes an API request like DoJSON, setting additional headers.
+func (api API) doJSON(method string, endpoint string, body interface{}, header http.Header) (io.ReadCloser, error) {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("could not encode body of %s: %v", endpoint, err)
+	}
+	req, err := api.newRequest(method, endpoint, bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key := range header {
+		req.Header.Set(key, header.Get(key))
+	}
+	res, err := api.send(req, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return res.Body, nil
+}
+
+// Upload executes a multipart API request containing values and files,
+// keyed by their field name. The method is a HTTP method, e.g. POST or PATCH.
+func (api API) Upload(method, endpoint string, values url.Values, files map[string]File, dest interface{}) error {
+	body := &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+	for key, vs := range values {
+		for _, v := range vs {
+			if err := w.WriteField(key, v); err != nil {
+				return fmt.Errorf("could not write field %s: %v", key, err)
+			}
+		}
+	}
+	for key, f := range files {
+		if err := writeFile(w, key, f); err != nil {
+			return fmt.Errorf("could not write file %s: %v", key, err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("could not write body of %s: %v", endpoint, err)
+	}
+
+	req, err := api.newRequest(method, endpoint, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", w.FormDataContentType())
+
+	res, err := api.send(req, endpoint)
+	if err != nil {
+		return fmt.Errorf("could not %s %s: %w", method, endpoint, err)
+	}
+	return api.decode(endpoint, res.Body, dest)
+}
+
+func writeFile(w *multipart.Writer, key string, f File) error {
+	r := bufio.NewReader(f.Reader)
+	contentType := f.ContentType
+	if contentType == "" {
+		contentType = mime.TypeByExtension(filepath.Ext(f.Name))
+	}
+	if contentType == "" {
+		head, _ := r.Peek(512)
+		contentType = http.DetectContentType(head)
+	}
+
+	h := textproto.MIMEHeader{}
+	h.Set("Content-Disposition", mime.FormatMediaType("form-data", map[string]string{
+		"name":     key,
+		"filename": f.Name,
+	}))
+	h.Set("Content-Type", contentType)
+	part, err := w.CreatePart(h)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(part, r)
+	return err
+}
+
+func (api API) newRequest(method, endpoint string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequest(method, api.Base+api.Prefix+endpoint, body)
+	if err != nil {
+		return nil, fmt.Errorf("could not create request to %s: %v", endpoint, err)
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", api.AccessToken))
+	return req, nil
+}
+
+func (api API) send(req *http.Request, endpoint string) (*http.Response, error) {
+	client := &http.Client{}
 	res, err := client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("could not execute %s: %v", endpoint, err)
@@ -41,10 +164,9 @@ func (api API) Do(method string, endpoint string, values url.Values) (io.ReadClo
 
 	switch res.StatusCode {
 	case http.StatusOK:
-		return res.Body, nil
+		return res, nil
 	default:
-		err := api.getError(res.Body)
-		return nil, fmt.Errorf("%s: %v", res.Status, err)
+		return nil, api.getError(res)
 	}
 }
 
@@ -58,29 +180,107 @@ func (api API) Post(endpoint string, values url.Values, dest interface{}) error
 	return api.generic(http.MethodPost, endpoint, values, dest)
 }
 
+// Put request
+func (api API) Put(endpoint string, values url.Values, dest interface{}) error {
+	return api.generic(http.MethodPut, endpoint, values, dest)
+}
+
+// Patch request
+func (api API) Patch(endpoint string, values url.Values, dest interface{}) error {
+	return api.generic(http.MethodPatch, endpoint, values, dest)
+}
+
 // Delete request
 func (api API) Delete(endpoint string, values url.Values, dest interface{}) error {
 	return api.generic(http.MethodDelete, endpoint, values, dest)
 }
 
+// PostJSON request with a JSON body
+func (api API) PostJSON(endpoint string, body interface{}, dest interface{}) error {
+	return api.genericJSON(http.MethodPost, endpoint, body, nil, dest)
+}
+
+// PutJSON request with a JSON body
+func (api API) PutJSON(endpoint string, body interface{}, dest interface{}) error {
+	return api.genericJSON(http.MethodPut, endpoint, body, nil, dest)
+}
+
+// PatchJSON request with a JSON body
+func (api API) PatchJSON(endpoint string, body interface{}, dest interface{}) error {
+	return api.genericJSON(http.MethodPatch, endpoint, body, nil, dest)
+}
+
+// withPrefix returns a copy of the API using another prefix, e.g. to reach
+// endpoints outside of /api/v1/.
+func (api API) withPrefix(prefix string) *API {
+	api.Prefix = prefix
+	return &api
+}
+
+// getPage sends a GET request like Get and returns the pages linked by the
+// response.
+func (api API) getPage(endpoint string, values url.Values, dest interface{}) (Page, error) {
+	res, err := api.do(http.MethodGet, endpoint, values)
+	if err != nil {
+		return Page{}, fmt.Errorf("could not %s %s: %w", http.MethodGet, endpoint, err)
+	}
+	return parseLink(res.Header.Get("Link")), api.decode(endpoint, res.Body, dest)
+}
+
 func (api API) generic(method, endpoint string, values url.Values, dest interface{}) error {
 	r, err := api.Do(method, endpoint, values)
 	if err != nil {
-		return fmt.Errorf("could not %s %s: %v", method, endpoint, err)
+		return fmt.Errorf("could not %s %s: %w", method, endpoint, err)
+	}
+	return api.decode(endpoint, r, dest)
+}
+
+func (api API) genericJSON(method, endpoint string, body interface{}, header http.Header, dest interface{}) error {
+	r, err := api.doJSON(method, endpoint, body, header)
+	if err != nil {
+		return fmt.Errorf("could not %s %s: %w", method, endpoint, err)
 	}
+	return api.decode(endpoint, r, dest)
+}
+
+// decode decodes the response into dest. The response is discarded if dest
+// is nil.
+func (api API) decode(endpoint string, r io.ReadCloser, dest interface{}) error {
 	defer r.Close()
 
+	if dest == nil {
+		_, err := io.Copy(io.Discard, r)
+		return err
+	}
 	if err := json.NewDecoder(r).Decode(dest); err != nil {
 		return fmt.Errorf("could not decode %s: %v", endpoint, err)
 	}
 	return nil
 }
 
-func (api API) getError(r io.ReadCloser) error {
-	defer r.Close()
-	res := Error{}
-	if err := json.NewDecoder(r).Decode(&res); err != nil {
-		return fmt.Errorf("could not decode error: %v", err)
+func (api API) getError(res *http.Response) error {
+	defer res.Body.Close()
+	e := Error{}
+	if err := json.NewDecoder(res.Body).Decode(&e); err != nil {
+		return fmt.Errorf("%s: could not decode error: %v", res.Status, err)
+	}
+	return &ResponseError{
+		StatusCode: res.StatusCode,
+		Status:     res.Status,
+		Message:    e.Error,
+		Details:    e.Details,
 	}
-	return errors.New(res.Error)
+}
+
+// ResponseError is returned if the server responds with an error. Use
+// errors.As to access it.
+type ResponseError struct {
+	StatusCode int                      // The HTTP status code, e.g. 422
+	Status     string                   // The HTTP status, e.g. "422 Unprocessable Entity"
+	Message    string                   // A textual description of the error
+	Details    map[string][]ErrorDetail // Validation errors by field name, if any
+}
+
+func (err *ResponseError) Error() string {
+	return fmt.Sprintf("%s: %s", err.Status, err.Message)
 }
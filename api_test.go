@@ -0,0 +1,85 @@
+package mastodon
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestDoBody(t *testing.T) {
+	tests := []struct {
+		method string
+		values url.Values
+		query  url.Values
+		body   string // Expected content type: "" or "form"
+		form   url.Values
+	}{
+		{method: http.MethodGet, values: url.Values{"a": {"1"}}, query: url.Values{"a": {"1"}}},
+		{method: http.MethodGet},
+		{method: http.MethodDelete, values: url.Values{"a": {"1"}}, query: url.Values{"a": {"1"}}},
+		{method: http.MethodPost, values: url.Values{"a": {"1"}}, body: "form", form: url.Values{"a": {"1"}}},
+		{method: http.MethodPost},
+		{method: http.MethodPut, values: url.Values{"a[]": {"1", "2"}}, body: "form", form: url.Values{"a[]": {"1", "2"}}},
+		{method: http.MethodPatch, values: url.Values{"a": {"1"}}, body: "form", form: url.Values{"a": {"1"}}},
+	}
+	for _, test := range tests {
+		t.Run(test.method, func(t *testing.T) {
+			srv, app := newFakeServer(t)
+			r, err := app.API.Do(test.method, "endpoint", test.values)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r.Close()
+
+			req := srv.last(t)
+			if req.Method != test.method || req.Path != "/api/v1/endpoint" {
+				t.Errorf("got %s %s, want %s /api/v1/endpoint", req.Method, req.Path, test.method)
+			}
+			if len(req.Query)+len(test.query) > 0 && !reflect.DeepEqual(req.Query, test.query) {
+				t.Errorf("query: got %v, want %v", req.Query, test.query)
+			}
+			checkBody(t, req, test.body, test.form, "")
+		})
+	}
+}
+
+func TestNilDestination(t *testing.T) {
+	_, app := newFakeServer(t, response{http.StatusOK, `{"id": "1"}`})
+	if err := app.API.Post("endpoint", nil, nil); err != nil {
+		t.Fatalf("got %v, want no error", err)
+	}
+}
+
+func TestResponseError(t *testing.T) {
+	_, app := newFakeServer(t, response{
+		http.StatusUnprocessableEntity,
+		`{"error": "Validation failed", "details": {"email": [{"error": "ERR_TAKEN", "description": "is already taken"}]}}`,
+	})
+	_, err := app.Accounts.Register(Registration{Username: "bob"})
+
+	resErr := &ResponseError{}
+	if !errors.As(err, &resErr) {
+		t.Fatalf("got %v, want *ResponseError", err)
+	}
+	if resErr.StatusCode != http.StatusUnprocessableEntity || resErr.Message != "Validation failed" {
+		t.Errorf("got %d %q", resErr.StatusCode, resErr.Message)
+	}
+	want := map[string][]ErrorDetail{"email": {{Error: "ERR_TAKEN", Description: "is already taken"}}}
+	if !reflect.DeepEqual(resErr.Details, want) {
+		t.Errorf("details: got %v, want %v", resErr.Details, want)
+	}
+}
+
+func TestDoJSON(t *testing.T) {
+	srv, app := newFakeServer(t)
+	r, err := app.API.DoJSON(http.MethodPut, "endpoint", map[string][]string{"a": {"1"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	io.Copy(io.Discard, r)
+	r.Close()
+	checkBody(t, srv.last(t), "json", nil, `{"a": ["1"]}`)
+}
@@ -0,0 +1,313 @@
+package mastodon
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	composerURLRegexp     = regexp.MustCompile(`(?i)\bhttps?://[^\s<>"]+`)
+	composerMentionRegexp = regexp.MustCompile(`(^|[^=/\p{L}\p{N}_])@([\p{L}\p{N}_]+(?:[\p{L}\p{N}_.-]+[\p{L}\p{N}_]+)?)@[\p{L}\p{N}_.-]+[\p{L}\p{N}_]`)
+
+	// Boundaries to split threads on, most preferred first.
+	composerBoundaries = []*regexp.Regexp{
+		regexp.MustCompile(`\n[ \t]*\n\s*`),
+		regexp.MustCompile(`[.!?…]+["')\]]*\s+`),
+		regexp.MustCompile(`\s+`),
+	}
+)
+
+// Composer validates statuses against the limits of an instance before
+// posting them. Limits of zero are not checked.
+type Composer struct {
+	statuses *Statuses
+
+	MaxCharacters            int // The maximum number of characters per status
+	CharactersReservedPerURL int // The number of characters each URL counts as
+	MaxMediaAttachments      int // The maximum number of attachments per status
+	MaxPollOptions           int // The maximum number of options per poll
+	MaxPollOptionCharacters  int // The maximum number of characters per option
+	MinPollExpiration        int // The shortest duration of a poll in seconds
+	MaxPollExpiration        int // The longest duration of a poll in seconds
+}
+
+// NewComposer returns a Composer posting using statuses and the limits of
+// conf.
+func NewComposer(statuses *Statuses, conf InstanceConfiguration) *Composer {
+	return &Composer{
+		statuses:                 statuses,
+		MaxCharacters:            conf.Statuses.MaxCharacters,
+		CharactersReservedPerURL: conf.Statuses.CharactersReservedPerURL,
+		MaxMediaAttachments:      conf.Statuses.MaxMediaAttachments,
+		MaxPollOptions:           conf.Polls.MaxOptions,
+		MaxPollOptionCharacters:  conf.Polls.MaxCharactersPerOption,
+		MinPollExpiration:        conf.Polls.MinExpiration,
+		MaxPollExpiration:        conf.Polls.MaxExpiration,
+	}
+}
+
+// Composer fetches the limits of the instance and returns a Composer.
+func (app App) Composer() (*Composer, error) {
+	i, err := app.Instances.GetV2()
+	if err != nil {
+		return nil, fmt.Errorf("could not get instance limits: %w", err)
+	}
+	return NewComposer(app.Statuses, i.Configuration), nil
+}
+
+// Violation describes a limit exceeded by a status.
+type Violation struct {
+	Field  string // The param violating the limit, e.g. "status" or "poll[options][1]"
+	Limit  int    // The maximum, or minimum if Actual is below it
+	Actual int    // The value violating the limit
+}
+
+func (v Violation) String() string {
+	if v.Actual < v.Limit {
+		return fmt.Sprintf("%s: %d is below the minimum of %d", v.Field, v.Actual, v.Limit)
+	}
+	return fmt.Sprintf("%s: %d exceeds the limit of %d", v.Field, v.Actual, v.Limit)
+}
+
+// ComposeError is returned if a status exceeds the limits of an instance.
+type ComposeError struct {
+	Violations []Violation
+}
+
+func (err *ComposeError) Error() string {
+	vs := []string{}
+	for _, v := range err.Violations {
+		vs = append(vs, v.String())
+	}
+	return "status exceeds limits: " + strings.Join(vs, "; ")
+}
+
+// Count returns the number of characters text counts as. Like Mastodon, URLs
+// count as CharactersReservedPerURL and mentions of remote accounts count
+// without their domain.
+func (c Composer) Count(text string) int {
+	if c.CharactersReservedPerURL > 0 {
+		placeholder := strings.Repeat("x", c.CharactersReservedPerURL)
+		text = composerURLRegexp.ReplaceAllStringFunc(text, func(u string) string {
+			trimmed := strings.TrimRight(u, ".,:;!?)]}'\"")
+			return placeholder + u[len(trimmed):]
+		})
+	}
+	text = composerMentionRegexp.ReplaceAllString(text, "$1@$2")
+	return countGraphemes(text)
+}
+
+// countGraphemes approximates the number of user-perceived characters by
+// skipping combining marks, emoji modifiers and characters joined by ZWJ, and
+// by counting pairs of regional indicators as single flags.
+func countGraphemes(s string) int {
+	n := 0
+	joined := false
+	flag := false
+	for _, r := range s {
+		switch {
+		case joined:
+			joined = false
+		case r == '\u200d':
+			joined = true
+		case unicode.In(r, unicode.Mn, unicode.Me) || (r >= 0x1f3fb && r <= 0x1f3ff):
+		case r >= 0x1f1e6 && r <= 0x1f1ff:
+			if !flag {
+				n++
+			}
+			flag = !flag
+			continue
+		default:
+			n++
+		}
+		flag = false
+	}
+	return n
+}
+
+// Validate checks a status and its options as accepted by Statuses.Update
+// against the limits. opts may be nil. A *ComposeError lists all
+// violations.
+func (c Composer) Validate(status string, opts *PostOptions) error {
+	if opts == nil {
+		opts = &PostOptions{}
+	}
+	violations := []Violation{}
+	check := func(field string, limit, actual int) {
+		if limit > 0 && actual > limit {
+			violations = append(violations, Violation{Field: field, Limit: limit, Actual: actual})
+		}
+	}
+
+	check("status", c.MaxCharacters, c.Count(opts.SpoilerText+status))
+	check("media_ids", c.MaxMediaAttachments, len(opts.MediaIDs))
+
+	if poll := opts.Poll; poll != nil {
+		if len(poll.Options) < 2 {
+			violations = append(violations, Violation{Field: "poll[options]", Limit: 2, Actual: len(poll.Options)})
+		}
+		check("poll[options]", c.MaxPollOptions, len(poll.Options))
+		for i, o := range poll.Options {
+			check(fmt.Sprintf("poll[options][%d]", i), c.MaxPollOptionCharacters, countGraphemes(o))
+		}
+		if c.MinPollExpiration > 0 && poll.ExpiresIn < c.MinPollExpiration {
+			violations = append(violations, Violation{Field: "poll[expires_in]", Limit: c.MinPollExpiration, Actual: poll.ExpiresIn})
+		}
+		check("poll[expires_in]", c.MaxPollExpiration, poll.ExpiresIn)
+	}
+
+	if len(violations) > 0 {
+		return &ComposeError{Violations: violations}
+	}
+	return nil
+}
+
+// Post validates a status and posts it using Statuses.Update. opts may be
+// nil.
+func (c Composer) Post(status string, opts *PostOptions) (Status, error) {
+	if err := c.Validate(status, opts); err != nil {
+		return Status{}, err
+	}
+	return c.statuses.Update(status, opts)
+}
+
+// ThreadOptions holds the params to post a thread. They apply to every part
+// of the thread.
+type ThreadOptions struct {
+	Numbered       bool       // Append the number of the part, e.g. "1/5"
+	SpoilerText    string     // Text to be shown as a warning before each part
+	Visibility     Visibility // The visibility of each part
+	InReplyToID    string     // The status the first part replies to
+	IdempotencyKey string     // Derives the keys of the parts so posting again skips posted parts; generated if empty
+}
+
+// ThreadError is returned if a thread was posted partially.
+type ThreadError struct {
+	Parts          []string // All parts of the thread
+	Posted         []Status // The parts that have been posted, in order
+	IdempotencyKey string   // The key to retry posting the thread with
+	Err            error    // The error posting Parts[len(Posted)]
+}
+
+func (err *ThreadError) Error() string {
+	return fmt.Sprintf("could not post part %d of %d: %v", len(err.Posted)+1, len(err.Parts), err.Err)
+}
+
+func (err *ThreadError) Unwrap() error {
+	return err.Err
+}
+
+// Split splits text into parts not exceeding MaxCharacters, including the
+// spoiler text shown on each part. Text is split on paragraphs, sentences or
+// words, whichever is the largest that fits. If numbered, the number of the
+// part is appended, e.g. "1/5". Text that fits is returned as is, as is text
+// that cannot be split because the spoiler text leaves no room for it.
+func (c Composer) Split(text, spoilerText string, numbered bool) []string {
+	text = strings.TrimSpace(text)
+	max := c.MaxCharacters - c.Count(spoilerText)
+	if c.MaxCharacters <= 0 || c.Count(text) <= max || max <= 0 {
+		return []string{text}
+	}
+	if !numbered {
+		return c.pack(text, max)
+	}
+
+	// The suffix depends on the number of parts, so reserve space for a
+	// growing number of digits until the parts fit.
+	parts := []string{}
+	for digits := 1; ; digits++ {
+		suffix := fmt.Sprintf("\n\n%s/%s", strings.Repeat("9", digits), strings.Repeat("9", digits))
+		if max-c.Count(suffix) <= 0 {
+			return []string{text}
+		}
+		parts = c.pack(text, max-c.Count(suffix))
+		if len(strconv.Itoa(len(parts))) <= digits {
+			break
+		}
+	}
+	for i := range parts {
+		parts[i] += fmt.Sprintf("\n\n%d/%d", i+1, len(parts))
+	}
+	return parts
+}
+
+// pack splits text into parts counting at most max characters.
+func (c Composer) pack(text string, max int) []string {
+	parts := []string{}
+	for text != "" {
+		if c.Count(text) <= max {
+			parts = append(parts, text)
+			break
+		}
+		n := c.cut(text, max)
+		parts = append(parts, strings.TrimSpace(text[:n]))
+		text = strings.TrimSpace(text[n:])
+	}
+	return parts
+}
+
+// cut returns the length of the longest prefix of text ending on the most
+// preferred boundary and counting at most max characters. If no boundary
+// fits, the text is cut within a word.
+func (c Composer) cut(text string, max int) int {
+	for _, re := range composerBoundaries {
+		n := 0
+		for _, m := range re.FindAllStringIndex(text, -1) {
+			if m[0] == 0 {
+				continue
+			}
+			if c.Count(strings.TrimSpace(text[:m[1]])) > max {
+				break
+			}
+			n = m[1]
+		}
+		if n > 0 {
+			return n
+		}
+	}
+
+	n := 0
+	for i, r := range text {
+		end := i + utf8.RuneLen(r)
+		if n > 0 && c.Count(text[:end]) > max {
+			break
+		}
+		n = end
+	}
+	return n
+}
+
+// PostThread splits text using Split and posts the parts as a thread, each
+// part replying to the previous one. A *ThreadError reports which parts have
+// been posted if posting fails midway. Passing its IdempotencyKey in opts
+// retries without posting duplicates.
+func (c Composer) PostThread(text string, opts ThreadOptions) ([]Status, error) {
+	if opts.IdempotencyKey == "" {
+		key, err := NewIdempotencyKey()
+		if err != nil {
+			return []Status{}, err
+		}
+		opts.IdempotencyKey = key
+	}
+	parts := c.Split(text, opts.SpoilerText, opts.Numbered)
+	posted := []Status{}
+	replyTo := opts.InReplyToID
+	for i, part := range parts {
+		s, err := c.Post(part, &PostOptions{
+			InReplyToID:    replyTo,
+			SpoilerText:    opts.SpoilerText,
+			Visibility:     opts.Visibility,
+			IdempotencyKey: fmt.Sprintf("%s-%d", opts.IdempotencyKey, i),
+		})
+		if err != nil {
+			return posted, &ThreadError{Parts: parts, Posted: posted, IdempotencyKey: opts.IdempotencyKey, Err: err}
+		}
+		posted = append(posted, s)
+		replyTo = s.ID
+	}
+	return posted, nil
+}
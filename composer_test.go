@@ -0,0 +1,187 @@
+package mastodon
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCount(t *testing.T) {
+	c := Composer{CharactersReservedPerURL: 23}
+	tests := []struct {
+		text string
+		want int
+	}{
+		{"hello", 5},
+		{"@bob@example.social hi", 7},
+		{"@bob hi", 7},
+		{"mail@example.social", 19},
+		{"see https://example.com/a/very/long/path.", 28},
+		{"(https://example.com/)", 25},
+		{"héllo", 5},
+		{"he\u0301llo", 5},
+		{"\U0001f44d\U0001f3fd", 1},
+		{"\U0001f469\u200d\U0001f4bb code", 6},
+		{"\U0001f1e9\U0001f1ea\U0001f1eb\U0001f1f7", 2},
+	}
+	for _, test := range tests {
+		if got := c.Count(test.text); got != test.want {
+			t.Errorf("Count(%q) = %d, want %d", test.text, got, test.want)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	c := Composer{MaxCharacters: 10, MaxMediaAttachments: 1, MaxPollOptions: 3, MaxPollOptionCharacters: 3, MinPollExpiration: 300}
+	err := c.Validate("hello", &PostOptions{
+		SpoilerText: "spoiler",
+		MediaIDs:    []string{"1", "2"},
+		Poll:        &PollOptions{Options: []string{"yes", "maybe", "no", "never"}, ExpiresIn: 60},
+	})
+	composeErr := &ComposeError{}
+	if !errors.As(err, &composeErr) {
+		t.Fatalf("got %v, want *ComposeError", err)
+	}
+	want := []Violation{
+		{Field: "status", Limit: 10, Actual: 12},
+		{Field: "media_ids", Limit: 1, Actual: 2},
+		{Field: "poll[options]", Limit: 3, Actual: 4},
+		{Field: "poll[options][1]", Limit: 3, Actual: 5},
+		{Field: "poll[options][3]", Limit: 3, Actual: 5},
+		{Field: "poll[expires_in]", Limit: 300, Actual: 60},
+	}
+	if !reflect.DeepEqual(composeErr.Violations, want) {
+		t.Errorf("got %v, want %v", composeErr.Violations, want)
+	}
+
+	if err := c.Validate("hello", nil); err != nil {
+		t.Errorf("got %v, want no error", err)
+	}
+}
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		name     string
+		max      int
+		text     string
+		spoiler  string
+		numbered bool
+		want     []string
+	}{
+		{"fits", 20, "  short  ", "", false, []string{"short"}},
+		{"paragraphs", 30, "First sentence. Second one.\n\nThird paragraph here.", "", false, []string{"First sentence. Second one.", "Third paragraph here."}},
+		{"sentences", 20, "Hi there. Some longer words", "", false, []string{"Hi there.", "Some longer words"}},
+		{"words", 8, "aaa bbb ccc ddd", "", false, []string{"aaa bbb", "ccc ddd"}},
+		{"runes", 4, "abcdefghij", "", false, []string{"abcd", "efgh", "ij"}},
+		{"spoiler", 20, "aaa bbb ccc ddd", "cw: spoiler", false, []string{"aaa bbb", "ccc ddd"}},
+		{"long spoiler", 20, "aaa bbb ccc ddd eee fff ggg", strings.Repeat("x", 25), false, []string{"aaa bbb ccc ddd eee fff ggg"}},
+		{"long suffix", 8, "aaa bbb ccc ddd", "cw", true, []string{"aaa bbb ccc ddd"}},
+		{"numbered", 12, "aaa bbb ccc ddd", "", true, []string{"aaa bbb\n\n1/2", "ccc ddd\n\n2/2"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := Composer{MaxCharacters: test.max}
+			got := c.Split(test.text, test.spoiler, test.numbered)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+			for _, part := range got {
+				if n := c.Count(test.spoiler + part); len(got) > 1 && n > test.max {
+					t.Errorf("part %q counts %d characters, want at most %d", part, n, test.max)
+				}
+			}
+		})
+	}
+}
+
+func TestSplitNumberedDigits(t *testing.T) {
+	c := Composer{MaxCharacters: 10}
+	text := strings.TrimSpace(strings.Repeat("word ", 12))
+
+	// A single digit leaves room for a word per part, requiring 12 parts
+	// and thus two digits, which leave room for only three characters.
+	parts := c.Split(text, "", true)
+	if len(parts) != 24 {
+		t.Fatalf("got %d parts, want 24", len(parts))
+	}
+	for i, part := range parts {
+		if n := c.Count(part); n > c.MaxCharacters {
+			t.Errorf("part %d counts %d characters, want at most %d", i, n, c.MaxCharacters)
+		}
+		if suffix := fmt.Sprintf("\n\n%d/%d", i+1, len(parts)); !strings.HasSuffix(part, suffix) {
+			t.Errorf("part %q does not end with %q", part, suffix)
+		}
+	}
+}
+
+func TestPostThreadError(t *testing.T) {
+	srv, app := newFakeServer(t,
+		response{http.StatusOK, `{"id": "1"}`},
+		response{http.StatusOK, `{"id": "2"}`},
+		response{http.StatusInternalServerError, `{"error": "oops"}`},
+	)
+	c := Composer{statuses: app.Statuses, MaxCharacters: 8}
+	posted, err := c.PostThread("aaa bbb ccc ddd eee fff", ThreadOptions{InReplyToID: "0"})
+
+	threadErr := &ThreadError{}
+	if !errors.As(err, &threadErr) {
+		t.Fatalf("got %v, want *ThreadError", err)
+	}
+	if want := []string{"aaa bbb", "ccc ddd", "eee fff"}; !reflect.DeepEqual(threadErr.Parts, want) {
+		t.Errorf("parts: got %q, want %q", threadErr.Parts, want)
+	}
+	if len(threadErr.Posted) != 2 || len(posted) != 2 || threadErr.Posted[1].ID != "2" {
+		t.Errorf("posted: got %v, want the first two parts", threadErr.Posted)
+	}
+	if !errors.As(err, new(*ResponseError)) {
+		t.Errorf("got %v, want to unwrap *ResponseError", err)
+	}
+
+	for i, want := range []string{"0", "1", "2"} {
+		body := struct {
+			InReplyToID string `json:"in_reply_to_id"`
+		}{}
+		if err := json.Unmarshal(srv.requests[i].Body, &body); err != nil {
+			t.Fatal(err)
+		}
+		if body.InReplyToID != want {
+			t.Errorf("part %d replies to %q, want %q", i, body.InReplyToID, want)
+		}
+	}
+}
+
+func TestPostThreadRetry(t *testing.T) {
+	srv, app := newFakeServer(t,
+		response{http.StatusOK, `{"id": "1"}`},
+		response{http.StatusServiceUnavailable, `{}`},
+		response{http.StatusOK, `{"id": "1"}`},
+		response{http.StatusOK, `{"id": "2"}`},
+	)
+	c := Composer{statuses: app.Statuses, MaxCharacters: 8}
+	_, err := c.PostThread("aaa bbb ccc ddd", ThreadOptions{})
+	threadErr := &ThreadError{}
+	if !errors.As(err, &threadErr) || threadErr.IdempotencyKey == "" {
+		t.Fatalf("got %v, want *ThreadError with a key", err)
+	}
+	posted, err := c.PostThread("aaa bbb ccc ddd", ThreadOptions{IdempotencyKey: threadErr.IdempotencyKey})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(posted) != 2 {
+		t.Fatalf("got %d posted parts, want 2", len(posted))
+	}
+
+	// The first attempt posted part 0 and failed on part 1; the retry posts
+	// both parts again using the same keys.
+	keys := []string{}
+	for _, req := range srv.requests {
+		keys = append(keys, req.Header.Get("Idempotency-Key"))
+	}
+	if len(keys) != 4 || keys[0] != keys[2] || keys[1] != keys[3] || keys[0] == keys[1] {
+		t.Errorf("got keys %q, want the same key per part across retries", keys)
+	}
+}
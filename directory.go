@@ -0,0 +1,34 @@
+package mastodon
+
+import "net/url"
+
+// Directory implements methods under /directory.
+type Directory struct {
+	api *API
+}
+
+// DirectoryOptions holds the params to filter the profile directory. Empty
+// values use the server's defaults.
+type DirectoryOptions struct {
+	Limit  int    // Maximum number of accounts to return
+	Offset int    // Skip this many accounts
+	Order  string // Either "active" for recently active or "new" for new accounts first
+	Local  bool   // Only return local accounts
+}
+
+// Get returns accounts that opted into being listed in the profile
+// directory. opts may be nil.
+func (directory Directory) Get(opts *DirectoryOptions) ([]Account, error) {
+	a := []Account{}
+	v := url.Values{}
+	if opts != nil {
+		v = offsetValues(opts.Limit, opts.Offset)
+		if opts.Order != "" {
+			v.Set("order", opts.Order)
+		}
+		if opts.Local {
+			v.Set("local", "true")
+		}
+	}
+	return a, directory.api.Get("directory", v, &a)
+}
@@ -0,0 +1,38 @@
+package mastodon
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// FeaturedTags implements methods under /featured_tags.
+type FeaturedTags struct {
+	api *API
+}
+
+// Get returns the hashtags featured on the authenticated user's profile.
+func (featuredTags FeaturedTags) Get() ([]FeaturedTag, error) {
+	t := []FeaturedTag{}
+	return t, featuredTags.api.Get("featured_tags", nil, &t)
+}
+
+// Feature features a hashtag, not including the preceding #, on the
+// authenticated user's profile.
+func (featuredTags FeaturedTags) Feature(name string) (FeaturedTag, error) {
+	t := FeaturedTag{}
+	v := url.Values{"name": {name}}
+	return t, featuredTags.api.Post("featured_tags", v, &t)
+}
+
+// Unfeature stops featuring a hashtag, given by the ID of the featured tag.
+func (featuredTags FeaturedTags) Unfeature(id string) error {
+	end := fmt.Sprintf("featured_tags/%s", id)
+	return featuredTags.api.Delete(end, nil, nil)
+}
+
+// Suggestions returns hashtags recently used by the authenticated user,
+// which are not featured yet.
+func (featuredTags FeaturedTags) Suggestions() ([]Tag, error) {
+	t := []Tag{}
+	return t, featuredTags.api.Get("featured_tags/suggestions", nil, &t)
+}
@@ -1,8 +1,6 @@
 package mastodon
 
-import (
-	"net/url"
-)
+import "fmt"
 
 // FollowRequests implements methods under /follow_requests.
 type FollowRequests struct {
@@ -16,19 +14,23 @@ func (followRequests FollowRequests) Get() ([]Account, error) {
 	return a, followRequests.api.Get("follow_requests", nil, &a)
 }
 
-// Authorize authorizes a follow request.
-func (followRequests FollowRequests) Authorize(id string) error {
-	v := url.Values{"id": {id}}
-	return followRequests.api.Post("follow_requests/authorize", v, nil)
+// Authorize authorizes a follow request of the given account and returns the
+// updated relationship.
+func (followRequests FollowRequests) Authorize(id string) (Relationship, error) {
+	rel := Relationship{}
+	end := fmt.Sprintf("follow_requests/%s/authorize", id)
+	return rel, followRequests.api.Post(end, nil, &rel)
 }
 
-// Reject rejects a follow request.
-func (followRequests FollowRequests) Reject(id string) error {
-	v := url.Values{"id": {id}}
-	return followRequests.api.Post("follow_requests/reject", v, nil)
+// Reject rejects a follow request of the given account and returns the
+// updated relationship.
+func (followRequests FollowRequests) Reject(id string) (Relationship, error) {
+	rel := Relationship{}
+	end := fmt.Sprintf("follow_requests/%s/reject", id)
+	return rel, followRequests.api.Post(end, nil, &rel)
 }
 
 // RejectFalseIcons rejects a follow request.
-func (followRequests FollowRequests) RejectFalseIcons(id string) error {
+func (followRequests FollowRequests) RejectFalseIcons(id string) (Relationship, error) {
 	return followRequests.Reject(id)
 }
@@ -8,6 +8,9 @@ type Follows struct {
 }
 
 // Follow a remote user.
+//
+// Deprecated: The endpoint has been removed in Mastodon 2.1. Resolve the
+// account using Search and follow it using Accounts.Follow instead.
 func (follows Follows) Follow(uri string) (Account, error) {
 	a := Account{}
 	v := url.Values{"uri": {uri}}
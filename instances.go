@@ -6,7 +6,60 @@ type Instances struct {
 }
 
 // Get returns the current instance. Does not require authentication.
+//
+// Deprecated: Use GetV2 instead.
 func (instances Instances) Get() (Instance, error) {
 	i := Instance{}
 	return i, instances.api.Get("instance", nil, &i)
 }
+
+// GetV2 returns the current instance including its configuration. Does not
+// require authentication.
+func (instances Instances) GetV2() (InstanceV2, error) {
+	i := InstanceV2{}
+	return i, instances.api.withPrefix("/api/v2/").Get("instance", nil, &i)
+}
+
+// Peers returns the domains of instances the current instance is aware of.
+func (instances Instances) Peers() ([]string, error) {
+	p := []string{}
+	return p, instances.api.Get("instance/peers", nil, &p)
+}
+
+// Activity returns weekly statistics of the past 12 weeks, most recent
+// first.
+func (instances Instances) Activity() ([]InstanceActivity, error) {
+	a := []InstanceActivity{}
+	return a, instances.api.Get("instance/activity", nil, &a)
+}
+
+// Rules returns the rules of the instance.
+func (instances Instances) Rules() ([]Rule, error) {
+	r := []Rule{}
+	return r, instances.api.Get("instance/rules", nil, &r)
+}
+
+// DomainBlocks returns the domains blocked by the instance, if the admins
+// chose to publish them.
+func (instances Instances) DomainBlocks() ([]DomainBlock, error) {
+	d := []DomainBlock{}
+	return d, instances.api.Get("instance/domain_blocks", nil, &d)
+}
+
+// ExtendedDescription returns the extended description of the instance.
+func (instances Instances) ExtendedDescription() (ExtendedDescription, error) {
+	d := ExtendedDescription{}
+	return d, instances.api.Get("instance/extended_description", nil, &d)
+}
+
+// PrivacyPolicy returns the privacy policy of the instance.
+func (instances Instances) PrivacyPolicy() (PrivacyPolicy, error) {
+	p := PrivacyPolicy{}
+	return p, instances.api.Get("instance/privacy_policy", nil, &p)
+}
+
+// Languages returns the languages supported by the instance.
+func (instances Instances) Languages() ([]Language, error) {
+	l := []Language{}
+	return l, instances.api.Get("instance/languages", nil, &l)
+}
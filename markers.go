@@ -0,0 +1,80 @@
+package mastodon
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// MarkerTimeline is a timeline which supports markers.
+type MarkerTimeline string
+
+// Timelines which support markers.
+const (
+	MarkerHome          MarkerTimeline = "home"
+	MarkerNotifications MarkerTimeline = "notifications"
+)
+
+// markersRetries is the number of attempts to save markers on conflicts.
+const markersRetries = 3
+
+// Markers implements methods under /markers.
+type Markers struct {
+	api *API
+}
+
+// Get returns the read positions of the given timelines, keyed by timeline.
+// All timelines are returned if none are given.
+func (markers Markers) Get(timelines ...MarkerTimeline) (map[MarkerTimeline]Marker, error) {
+	if len(timelines) == 0 {
+		timelines = []MarkerTimeline{MarkerHome, MarkerNotifications}
+	}
+	m := map[MarkerTimeline]Marker{}
+	v := url.Values{}
+	for _, timeline := range timelines {
+		v.Add("timeline[]", string(timeline))
+	}
+	return m, markers.api.Get("markers", v, &m)
+}
+
+// Save saves the read positions of timelines given as last read IDs keyed by
+// timeline, and returns the resulting markers. Another client may have
+// saved a marker at the same time, in which case the server's markers are
+// fetched and the save is retried for timelines the server has not read
+// past yet. The result contains the markers of all given timelines.
+func (markers Markers) Save(lastReadIDs map[MarkerTimeline]string) (map[MarkerTimeline]Marker, error) {
+	result := map[MarkerTimeline]Marker{}
+	for i := 0; ; i++ {
+		m := map[MarkerTimeline]Marker{}
+		v := url.Values{}
+		for timeline, id := range lastReadIDs {
+			v.Set(fmt.Sprintf("%s[last_read_id]", timeline), id)
+		}
+		err := markers.api.Post("markers", v, &m)
+		resErr := &ResponseError{}
+		if !errors.As(err, &resErr) || resErr.StatusCode != http.StatusConflict || i+1 >= markersRetries {
+			for timeline, marker := range m {
+				result[timeline] = marker
+			}
+			return result, err
+		}
+
+		current, err := markers.Get()
+		if err != nil {
+			return result, err
+		}
+		pending := map[MarkerTimeline]string{}
+		for timeline, id := range lastReadIDs {
+			if compareIDs(current[timeline].LastReadID, id) < 0 {
+				pending[timeline] = id
+				continue
+			}
+			result[timeline] = current[timeline]
+		}
+		if len(pending) == 0 {
+			return result, nil
+		}
+		lastReadIDs = pending
+	}
+}
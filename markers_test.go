@@ -0,0 +1,51 @@
+package mastodon
+
+import (
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestMarkersSaveConflict(t *testing.T) {
+	srv, app := newFakeServer(t,
+		response{http.StatusConflict, `{"error": "Conflict during update, please try again"}`},
+		response{http.StatusOK, `{"home": {"last_read_id": "20"}, "notifications": {"last_read_id": "5"}}`},
+		response{http.StatusOK, `{"notifications": {"last_read_id": "10"}}`},
+	)
+	m, err := app.Markers.Save(map[MarkerTimeline]string{
+		MarkerHome:          "15",
+		MarkerNotifications: "10",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The server has read past home, so only notifications are saved again.
+	want := url.Values{"notifications[last_read_id]": {"10"}}
+	if got := srv.last(t).Form; !reflect.DeepEqual(got, want) {
+		t.Errorf("retry: got %v, want %v", got, want)
+	}
+	if m[MarkerHome].LastReadID != "20" || m[MarkerNotifications].LastReadID != "10" {
+		t.Errorf("got %v, want home at 20 and notifications at 10", m)
+	}
+}
+
+func TestMarkersSaveRetries(t *testing.T) {
+	conflict := response{http.StatusConflict, `{}`}
+	current := response{http.StatusOK, `{"home": {"last_read_id": "1"}}`}
+	srv, app := newFakeServer(t, conflict, current, conflict, current, conflict)
+	_, err := app.Markers.Save(map[MarkerTimeline]string{MarkerHome: "2"})
+	if err == nil {
+		t.Fatal("got no error, want conflict")
+	}
+	posts := 0
+	for _, req := range srv.requests {
+		if req.Method == http.MethodPost {
+			posts++
+		}
+	}
+	if posts != markersRetries {
+		t.Errorf("got %d attempts, want %d", posts, markersRetries)
+	}
+}
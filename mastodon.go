@@ -12,19 +12,27 @@ import (
 type App struct {
 	Token          *oauth2.Token
 	Config         *oauth2.Config
+	Store          TokenStore
 	API            *API
 	Accounts       *Accounts
 	Blocks         *Blocks
+	Directory      *Directory
 	Favourites     *Favourites
+	FeaturedTags   *FeaturedTags
 	FollowRequests *FollowRequests
 	Follows        *Follows
 	Instances      *Instances
+	Markers        *Markers
 	Mutes          *Mutes
 	Notifications  *Notifications
+	Push           *Push
 	Reports        *Reports
 	Search         *Search
 	Statuses       *Statuses
+	Suggestions    *Suggestions
+	Tags           *Tags
 	Timelines      *Timelines
+	Trends         *Trends
 }
 
 // NewApp tries to register a new app.
@@ -49,31 +57,44 @@ func NewApp(base, name, uris string, scopes []string, website string) (*App, err
 		uris = "urn:ietf:wg:oauth:2.0:oob"
 	}
 
-	return &App{
-		Config: &oauth2.Config{
-			ClientID:     app.ClientID,
-			ClientSecret: app.ClientSecret,
-			RedirectURL:  uris,
-			Scopes:       scopes,
-			Endpoint: oauth2.Endpoint{
-				AuthURL:  api.Base + "/oauth/authorize",
-				TokenURL: api.Base + "/oauth/token",
-			},
+	config := &oauth2.Config{
+		ClientID:     app.ClientID,
+		ClientSecret: app.ClientSecret,
+		RedirectURL:  uris,
+		Scopes:       scopes,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  api.Base + "/oauth/authorize",
+			TokenURL: api.Base + "/oauth/token",
 		},
-		API:            &api,
-		Accounts:       &Accounts{&api},
-		Blocks:         &Blocks{&api},
-		Favourites:     &Favourites{&api},
-		FollowRequests: &FollowRequests{&api},
-		Follows:        &Follows{&api},
-		Instances:      &Instances{&api},
-		Mutes:          &Mutes{&api},
-		Notifications:  &Notifications{&api},
-		Reports:        &Reports{&api},
-		Search:         &Search{&api},
-		Statuses:       &Statuses{&api},
-		Timelines:      &Timelines{&api},
-	}, nil
+	}
+	return newApp(&api, config), nil
+}
+
+// newApp returns an App using api for all groups of methods.
+func newApp(api *API, config *oauth2.Config) *App {
+	return &App{
+		Config:         config,
+		API:            api,
+		Accounts:       &Accounts{api: api},
+		Blocks:         &Blocks{api},
+		Directory:      &Directory{api},
+		Favourites:     &Favourites{api},
+		FeaturedTags:   &FeaturedTags{api},
+		FollowRequests: &FollowRequests{api},
+		Follows:        &Follows{api},
+		Instances:      &Instances{api},
+		Markers:        &Markers{api},
+		Mutes:          &Mutes{api},
+		Notifications:  &Notifications{api},
+		Push:           &Push{api},
+		Reports:        &Reports{api},
+		Search:         &Search{api},
+		Statuses:       &Statuses{api},
+		Suggestions:    &Suggestions{api},
+		Tags:           &Tags{api},
+		Timelines:      &Timelines{api},
+		Trends:         &Trends{api},
+	}
 }
 
 // AuthCodeURL builds a URL to obtain an AccessCode.
@@ -91,7 +112,53 @@ func (app App) Exchange(code string) (string, error) {
 	return token.AccessToken, nil
 }
 
+// AppToken obtains a token that authenticates the app rather than an user,
+// e.g. to register new accounts.
+func (app App) AppToken() (Token, error) {
+	t := Token{}
+	v := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {app.Config.ClientID},
+		"client_secret": {app.Config.ClientSecret},
+		"redirect_uri":  {app.Config.RedirectURL},
+		"scope":         {strings.Join(app.Config.Scopes, " ")},
+	}
+	return t, app.API.withPrefix("/oauth/").Post("token", v, &t)
+}
+
 // SetToken saves the AccessToken in struct.
 func (app App) SetToken(token string) {
 	app.API.AccessToken = token
 }
+
+// Revoke invalidates an AccessToken. It can not be used to authenticate
+// afterwards.
+func (app App) Revoke(token string) error {
+	v := url.Values{
+		"client_id":     {app.Config.ClientID},
+		"client_secret": {app.Config.ClientSecret},
+		"token":         {token},
+	}
+	return app.API.withPrefix("/oauth/").Post("revoke", v, &struct{}{})
+}
+
+// Logout revokes the current AccessToken, removes it from the Store, if
+// there is one, and unsets it.
+func (app App) Logout() error {
+	token := app.API.AccessToken
+	if err := app.Revoke(token); err != nil {
+		return err
+	}
+	if app.Store != nil {
+		if err := app.Store.Delete(token); err != nil {
+			return fmt.Errorf("could not delete token from store: %w", err)
+		}
+	}
+	app.SetToken("")
+	return nil
+}
+
+// TokenStore persists AccessTokens, e.g. in a file or database.
+type TokenStore interface {
+	Delete(token string) error
+}
@@ -0,0 +1,874 @@
+package mastodon
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// request is a request received by a fakeServer.
+type request struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+	Form   url.Values // The parsed body of form and multipart requests
+}
+
+// response is a response sent by a fakeServer.
+type response struct {
+	Status int
+	Body   string
+}
+
+// fakeServer records requests and answers them with responses in order,
+// repeating the last one.
+type fakeServer struct {
+	*httptest.Server
+	mu        sync.Mutex
+	requests  []request
+	responses []response
+	header    http.Header // Headers sent with every response
+}
+
+// newFakeServer starts a fakeServer and returns an App using it.
+func newFakeServer(t *testing.T, responses ...response) (*fakeServer, *App) {
+	t.Helper()
+	if len(responses) == 0 {
+		responses = []response{{http.StatusOK, "{}"}}
+	}
+	srv := &fakeServer{responses: responses}
+	srv.Server = httptest.NewServer(http.HandlerFunc(srv.handle))
+	t.Cleanup(srv.Close)
+
+	api := &API{Base: srv.URL, Prefix: "/api/v1/", AccessToken: "token"}
+	app := newApp(api, &oauth2.Config{ClientID: "id", ClientSecret: "secret"})
+	return srv, app
+}
+
+func (srv *fakeServer) handle(w http.ResponseWriter, r *http.Request) {
+	req := request{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.Query(),
+		Header: r.Header,
+	}
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch mediaType {
+	case "multipart/form-data":
+		if err := r.ParseMultipartForm(1 << 20); err == nil {
+			req.Form = r.MultipartForm.Value
+		}
+	default:
+		req.Body, _ = io.ReadAll(r.Body)
+		if mediaType == "application/x-www-form-urlencoded" {
+			req.Form, _ = url.ParseQuery(string(req.Body))
+		}
+	}
+
+	srv.mu.Lock()
+	res := srv.responses[0]
+	if len(srv.responses) > 1 {
+		srv.responses = srv.responses[1:]
+	}
+	srv.requests = append(srv.requests, req)
+	for key, values := range srv.header {
+		w.Header()[key] = values
+	}
+	srv.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(res.Status)
+	fmt.Fprint(w, res.Body)
+}
+
+// last returns the most recent request.
+func (srv *fakeServer) last(t *testing.T) request {
+	t.Helper()
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	if len(srv.requests) == 0 {
+		t.Fatal("no request received")
+	}
+	return srv.requests[len(srv.requests)-1]
+}
+
+func TestEndpoints(t *testing.T) {
+	yes := true
+	tests := []struct {
+		name   string
+		call   func(app *App) error
+		method string
+		path   string
+		query  url.Values
+		body   string     // Expected content type: "", "form", "multipart" or "json"
+		form   url.Values // Expected form values for "form" and "multipart"; only the type is checked if nil
+		json   string     // Expected JSON for "json"; only the type is checked if empty
+		res    string     // Response body; defaults to "{}"
+	}{
+		{
+			name: "Accounts.Register",
+			call: func(app *App) error {
+				_, err := app.Accounts.Register(Registration{Username: "bob", Email: "bob@example.com", Password: "secret", Agreement: true, Locale: "en", InviteCode: "abc"})
+				return err
+			},
+			method: http.MethodPost, path: "/api/v1/accounts", body: "form",
+			form: url.Values{"username": {"bob"}, "email": {"bob@example.com"}, "password": {"secret"}, "agreement": {"true"}, "locale": {"en"}, "invite_code": {"abc"}},
+		},
+		{
+			name:   "Accounts.VerifyCredentials",
+			call:   func(app *App) error { _, err := app.Accounts.VerifyCredentials(); return err },
+			method: http.MethodGet, path: "/api/v1/accounts/verify_credentials",
+		},
+		{
+			name: "Accounts.UpdateCredentials",
+			call: func(app *App) error {
+				name := "Bob"
+				_, err := app.Accounts.UpdateCredentials(Credentials{DisplayName: &name, Bot: &yes, Fields: []Field{{Name: "web", Value: "example.com"}}, Privacy: "unlisted"})
+				return err
+			},
+			method: http.MethodPatch, path: "/api/v1/accounts/update_credentials", body: "json",
+			json: `{"display_name": "Bob", "bot": true, "fields_attributes": [{"name": "web", "value": "example.com"}], "source": {"privacy": "unlisted"}}`,
+		},
+		{
+			name: "Accounts.UpdateCredentials with avatar",
+			call: func(app *App) error {
+				avatar := &File{Name: "avatar.png", ContentType: "image/png", Reader: strings.NewReader("png")}
+				_, err := app.Accounts.UpdateCredentials(Credentials{Avatar: avatar, Fields: []Field{{Name: "web", Value: "example.com"}}, Privacy: "unlisted"})
+				return err
+			},
+			method: http.MethodPatch, path: "/api/v1/accounts/update_credentials", body: "multipart",
+			form: url.Values{"fields_attributes[0][name]": {"web"}, "fields_attributes[0][value]": {"example.com"}, "source[privacy]": {"unlisted"}},
+		},
+		{
+			name:   "Accounts.Get",
+			call:   func(app *App) error { _, err := app.Accounts.Get("1"); return err },
+			method: http.MethodGet, path: "/api/v1/accounts/1",
+		},
+		{
+			name:   "Accounts.Lookup",
+			call:   func(app *App) error { _, err := app.Accounts.Lookup("bob@example.com"); return err },
+			method: http.MethodGet, path: "/api/v1/accounts/lookup", query: url.Values{"acct": {"bob@example.com"}},
+		},
+		{
+			name:   "Accounts.FeaturedTags",
+			call:   func(app *App) error { _, err := app.Accounts.FeaturedTags("1"); return err },
+			method: http.MethodGet, path: "/api/v1/accounts/1/featured_tags", res: "[]",
+		},
+		{
+			name:   "Accounts.Followers",
+			call:   func(app *App) error { _, err := app.Accounts.Followers("1"); return err },
+			method: http.MethodGet, path: "/api/v1/accounts/1/followers", res: "[]",
+		},
+		{
+			name:   "Accounts.Following",
+			call:   func(app *App) error { _, err := app.Accounts.Following("1"); return err },
+			method: http.MethodGet, path: "/api/v1/accounts/1/following", res: "[]",
+		},
+		{
+			name: "Accounts.Statuses",
+			call: func(app *App) error {
+				_, err := app.Accounts.Statuses("1", &AccountStatusesOptions{Pagination: Pagination{MaxID: "9"}, ExcludeReplies: true})
+				return err
+			},
+			method: http.MethodGet, path: "/api/v1/accounts/1/statuses", res: "[]",
+			query: url.Values{"max_id": {"9"}, "exclude_replies": {"true"}},
+		},
+		{
+			name:   "Accounts.Follow",
+			call:   func(app *App) error { _, err := app.Accounts.Follow("1", nil); return err },
+			method: http.MethodPost, path: "/api/v1/accounts/1/follow",
+		},
+		{
+			name: "Accounts.Follow with options",
+			call: func(app *App) error {
+				_, err := app.Accounts.Follow("1", &FollowOptions{Notify: &yes, Languages: []string{"de", "en"}})
+				return err
+			},
+			method: http.MethodPost, path: "/api/v1/accounts/1/follow", body: "form",
+			form: url.Values{"notify": {"true"}, "languages[]": {"de", "en"}},
+		},
+		{
+			name:   "Accounts.Unfollow",
+			call:   func(app *App) error { _, err := app.Accounts.Unfollow("1"); return err },
+			method: http.MethodPost, path: "/api/v1/accounts/1/unfollow",
+		},
+		{
+			name:   "Accounts.Block",
+			call:   func(app *App) error { _, err := app.Accounts.Block("1"); return err },
+			method: http.MethodPost, path: "/api/v1/accounts/1/block",
+		},
+		{
+			name:   "Accounts.Unblock",
+			call:   func(app *App) error { _, err := app.Accounts.Unblock("1"); return err },
+			method: http.MethodPost, path: "/api/v1/accounts/1/unblock",
+		},
+		{
+			name:   "Accounts.Mute",
+			call:   func(app *App) error { _, err := app.Accounts.Mute("1"); return err },
+			method: http.MethodPost, path: "/api/v1/accounts/1/mute",
+		},
+		{
+			name:   "Accounts.Unmute",
+			call:   func(app *App) error { _, err := app.Accounts.Unmute("1"); return err },
+			method: http.MethodPost, path: "/api/v1/accounts/1/unmute",
+		},
+		{
+			name:   "Accounts.RelationshipsByID",
+			call:   func(app *App) error { _, err := app.Accounts.RelationshipsByID([]string{"1", "2"}, true); return err },
+			method: http.MethodGet, path: "/api/v1/accounts/relationships", res: "[]",
+			query: url.Values{"id[]": {"1", "2"}, "with_suspended": {"true"}},
+		},
+		{
+			name:   "Accounts.Search",
+			call:   func(app *App) error { _, err := app.Accounts.Search("bob", 5); return err },
+			method: http.MethodGet, path: "/api/v1/accounts/search", res: "[]",
+			query: url.Values{"q": {"bob"}, "limit": {"5"}},
+		},
+		{
+			name:   "Accounts.Relationships",
+			call:   func(app *App) error { _, err := app.Accounts.Relationships(1, 2); return err },
+			method: http.MethodGet, path: "/api/v1/accounts/relationships", res: "[]",
+			query: url.Values{"id[]": {"1", "2"}, "with_suspended": {"false"}},
+		},
+		{
+			name:   "Blocks.Get",
+			call:   func(app *App) error { _, err := app.Blocks.Get(); return err },
+			method: http.MethodGet, path: "/api/v1/blocks", res: "[]",
+		},
+		{
+			name: "Directory.Get",
+			call: func(app *App) error {
+				_, err := app.Directory.Get(&DirectoryOptions{Limit: 10, Order: "new", Local: true})
+				return err
+			},
+			method: http.MethodGet, path: "/api/v1/directory", res: "[]",
+			query: url.Values{"limit": {"10"}, "order": {"new"}, "local": {"true"}},
+		},
+		{
+			name:   "Favourites.Get",
+			call:   func(app *App) error { _, err := app.Favourites.Get(); return err },
+			method: http.MethodGet, path: "/api/v1/favourites", res: "[]",
+		},
+		{
+			name:   "FeaturedTags.Get",
+			call:   func(app *App) error { _, err := app.FeaturedTags.Get(); return err },
+			method: http.MethodGet, path: "/api/v1/featured_tags", res: "[]",
+		},
+		{
+			name:   "FeaturedTags.Feature",
+			call:   func(app *App) error { _, err := app.FeaturedTags.Feature("golang"); return err },
+			method: http.MethodPost, path: "/api/v1/featured_tags", body: "form", form: url.Values{"name": {"golang"}},
+		},
+		{
+			name:   "FeaturedTags.Unfeature",
+			call:   func(app *App) error { return app.FeaturedTags.Unfeature("1") },
+			method: http.MethodDelete, path: "/api/v1/featured_tags/1",
+		},
+		{
+			name:   "FeaturedTags.Suggestions",
+			call:   func(app *App) error { _, err := app.FeaturedTags.Suggestions(); return err },
+			method: http.MethodGet, path: "/api/v1/featured_tags/suggestions", res: "[]",
+		},
+		{
+			name:   "FollowRequests.Get",
+			call:   func(app *App) error { _, err := app.FollowRequests.Get(); return err },
+			method: http.MethodGet, path: "/api/v1/follow_requests", res: "[]",
+		},
+		{
+			name:   "FollowRequests.Authorize",
+			call:   func(app *App) error { _, err := app.FollowRequests.Authorize("1"); return err },
+			method: http.MethodPost, path: "/api/v1/follow_requests/1/authorize",
+		},
+		{
+			name:   "FollowRequests.Reject",
+			call:   func(app *App) error { _, err := app.FollowRequests.Reject("1"); return err },
+			method: http.MethodPost, path: "/api/v1/follow_requests/1/reject",
+		},
+		{
+			name:   "FollowRequests.RejectFalseIcons",
+			call:   func(app *App) error { _, err := app.FollowRequests.RejectFalseIcons("1"); return err },
+			method: http.MethodPost, path: "/api/v1/follow_requests/1/reject",
+		},
+		{
+			name:   "Follows.Follow",
+			call:   func(app *App) error { _, err := app.Follows.Follow("bob@example.com"); return err },
+			method: http.MethodPost, path: "/api/v1/follows", body: "form", form: url.Values{"uri": {"bob@example.com"}},
+		},
+		{
+			name:   "Instances.Get",
+			call:   func(app *App) error { _, err := app.Instances.Get(); return err },
+			method: http.MethodGet, path: "/api/v1/instance",
+		},
+		{
+			name:   "Instances.GetV2",
+			call:   func(app *App) error { _, err := app.Instances.GetV2(); return err },
+			method: http.MethodGet, path: "/api/v2/instance",
+		},
+		{
+			name:   "Instances.Peers",
+			call:   func(app *App) error { _, err := app.Instances.Peers(); return err },
+			method: http.MethodGet, path: "/api/v1/instance/peers", res: "[]",
+		},
+		{
+			name:   "Instances.Activity",
+			call:   func(app *App) error { _, err := app.Instances.Activity(); return err },
+			method: http.MethodGet, path: "/api/v1/instance/activity", res: "[]",
+		},
+		{
+			name:   "Instances.Rules",
+			call:   func(app *App) error { _, err := app.Instances.Rules(); return err },
+			method: http.MethodGet, path: "/api/v1/instance/rules", res: "[]",
+		},
+		{
+			name:   "Instances.DomainBlocks",
+			call:   func(app *App) error { _, err := app.Instances.DomainBlocks(); return err },
+			method: http.MethodGet, path: "/api/v1/instance/domain_blocks", res: "[]",
+		},
+		{
+			name:   "Instances.ExtendedDescription",
+			call:   func(app *App) error { _, err := app.Instances.ExtendedDescription(); return err },
+			method: http.MethodGet, path: "/api/v1/instance/extended_description",
+		},
+		{
+			name:   "Instances.PrivacyPolicy",
+			call:   func(app *App) error { _, err := app.Instances.PrivacyPolicy(); return err },
+			method: http.MethodGet, path: "/api/v1/instance/privacy_policy",
+		},
+		{
+			name:   "Instances.Languages",
+			call:   func(app *App) error { _, err := app.Instances.Languages(); return err },
+			method: http.MethodGet, path: "/api/v1/instance/languages", res: "[]",
+		},
+		{
+			name:   "Markers.Get",
+			call:   func(app *App) error { _, err := app.Markers.Get(); return err },
+			method: http.MethodGet, path: "/api/v1/markers",
+			query: url.Values{"timeline[]": {"home", "notifications"}},
+		},
+		{
+			name:   "Mutes.Get",
+			call:   func(app *App) error { _, err := app.Mutes.Get(); return err },
+			method: http.MethodGet, path: "/api/v1/mutes", res: "[]",
+		},
+		{
+			name: "Notifications.Get",
+			call: func(app *App) error {
+				_, err := app.Notifications.Get(&NotificationOptions{Types: []NotificationType{NotificationMention, NotificationStatus}, AccountID: "1"})
+				return err
+			},
+			method: http.MethodGet, path: "/api/v1/notifications", res: "[]",
+			query: url.Values{"types[]": {"mention", "status"}, "account_id": {"1"}},
+		},
+		{
+			name:   "Notifications.GetSingle",
+			call:   func(app *App) error { _, err := app.Notifications.GetSingle("1"); return err },
+			method: http.MethodGet, path: "/api/v1/notifications/1",
+		},
+		{
+			name:   "Notifications.Clear",
+			call:   func(app *App) error { return app.Notifications.Clear() },
+			method: http.MethodPost, path: "/api/v1/notifications/clear",
+		},
+		{
+			name:   "Notifications.Dismiss",
+			call:   func(app *App) error { return app.Notifications.Dismiss("1") },
+			method: http.MethodPost, path: "/api/v1/notifications/1/dismiss",
+		},
+		{
+			name:   "Notifications.UnreadCount",
+			call:   func(app *App) error { _, err := app.Notifications.UnreadCount(nil); return err },
+			method: http.MethodGet, path: "/api/v1/notifications/unread_count",
+		},
+		{
+			name: "Notifications.Groups",
+			call: func(app *App) error {
+				_, err := app.Notifications.Groups(&GroupedNotificationOptions{GroupedTypes: []NotificationType{NotificationFavourite}, ExpandAccounts: "partial_avatars"})
+				return err
+			},
+			method: http.MethodGet, path: "/api/v2/notifications",
+			query: url.Values{"grouped_types[]": {"favourite"}, "expand_accounts": {"partial_avatars"}},
+		},
+		{
+			name:   "Notifications.Group",
+			call:   func(app *App) error { _, err := app.Notifications.Group("favourite-1"); return err },
+			method: http.MethodGet, path: "/api/v2/notifications/favourite-1",
+		},
+		{
+			name:   "Notifications.DismissGroup",
+			call:   func(app *App) error { return app.Notifications.DismissGroup("favourite-1") },
+			method: http.MethodPost, path: "/api/v2/notifications/favourite-1/dismiss",
+		},
+		{
+			name:   "Notifications.GroupAccounts",
+			call:   func(app *App) error { _, err := app.Notifications.GroupAccounts("favourite-1"); return err },
+			method: http.MethodGet, path: "/api/v2/notifications/favourite-1/accounts", res: "[]",
+		},
+		{
+			name:   "Notifications.Policy",
+			call:   func(app *App) error { _, err := app.Notifications.Policy(); return err },
+			method: http.MethodGet, path: "/api/v2/notifications/policy",
+		},
+		{
+			name: "Notifications.UpdatePolicy",
+			call: func(app *App) error {
+				_, err := app.Notifications.UpdatePolicy(NotificationPolicy{ForNewAccounts: PolicyFilter})
+				return err
+			},
+			method: http.MethodPatch, path: "/api/v2/notifications/policy", body: "json",
+			json: `{"for_new_accounts": "filter"}`,
+		},
+		{
+			name:   "Notifications.Requests",
+			call:   func(app *App) error { _, err := app.Notifications.Requests(&Pagination{Limit: 5}); return err },
+			method: http.MethodGet, path: "/api/v1/notifications/requests", res: "[]",
+			query: url.Values{"limit": {"5"}},
+		},
+		{
+			name:   "Notifications.Request",
+			call:   func(app *App) error { _, err := app.Notifications.Request("1"); return err },
+			method: http.MethodGet, path: "/api/v1/notifications/requests/1",
+		},
+		{
+			name:   "Notifications.AcceptRequest",
+			call:   func(app *App) error { return app.Notifications.AcceptRequest("1") },
+			method: http.MethodPost, path: "/api/v1/notifications/requests/1/accept",
+		},
+		{
+			name:   "Notifications.DismissRequest",
+			call:   func(app *App) error { return app.Notifications.DismissRequest("1") },
+			method: http.MethodPost, path: "/api/v1/notifications/requests/1/dismiss",
+		},
+		{
+			name:   "Notifications.AcceptRequests",
+			call:   func(app *App) error { return app.Notifications.AcceptRequests("1", "2") },
+			method: http.MethodPost, path: "/api/v1/notifications/requests/accept", body: "form",
+			form: url.Values{"id[]": {"1", "2"}},
+		},
+		{
+			name:   "Notifications.DismissRequests",
+			call:   func(app *App) error { return app.Notifications.DismissRequests("1", "2") },
+			method: http.MethodPost, path: "/api/v1/notifications/requests/dismiss", body: "form",
+			form: url.Values{"id[]": {"1", "2"}},
+		},
+		{
+			name:   "Notifications.RequestsMerged",
+			call:   func(app *App) error { _, err := app.Notifications.RequestsMerged(); return err },
+			method: http.MethodGet, path: "/api/v1/notifications/requests/merged",
+		},
+		{
+			name: "Push.Subscribe",
+			call: func(app *App) error {
+				keys, err := NewPushKeys()
+				if err != nil {
+					return err
+				}
+				_, err = app.Push.Subscribe("https://example.com/push", keys, PushAlerts{Mention: true}, "")
+				return err
+			},
+			method: http.MethodPost, path: "/api/v1/push/subscription", body: "json",
+		},
+		{
+			name:   "Push.Get",
+			call:   func(app *App) error { _, err := app.Push.Get(); return err },
+			method: http.MethodGet, path: "/api/v1/push/subscription",
+		},
+		{
+			name:   "Push.Update",
+			call:   func(app *App) error { _, err := app.Push.Update(PushAlerts{Follow: true}, ""); return err },
+			method: http.MethodPut, path: "/api/v1/push/subscription", body: "json",
+			json: `{"data": {"alerts": {"mention": false, "status": false, "reblog": false, "follow": true, "follow_request": false, "favourite": false, "poll": false, "update": false, "admin.sign_up": false, "admin.report": false}}}`,
+		},
+		{
+			name:   "Push.Unsubscribe",
+			call:   func(app *App) error { return app.Push.Unsubscribe() },
+			method: http.MethodDelete, path: "/api/v1/push/subscription",
+		},
+		{
+			name:   "Reports.Get",
+			call:   func(app *App) error { _, err := app.Reports.Get(); return err },
+			method: http.MethodGet, path: "/api/v1/reports", res: "[]",
+		},
+		{
+			name:   "Reports.Report",
+			call:   func(app *App) error { _, err := app.Reports.Report("1", "2", "spam"); return err },
+			method: http.MethodPost, path: "/api/v1/reports", body: "form",
+			form: url.Values{"account_id": {"1"}, "status_ids[]": {"2"}, "comment": {"spam"}},
+		},
+		{
+			name:   "Reports.Report without status",
+			call:   func(app *App) error { _, err := app.Reports.Report("1", "", "spam"); return err },
+			method: http.MethodPost, path: "/api/v1/reports", body: "form",
+			form: url.Values{"account_id": {"1"}, "comment": {"spam"}},
+		},
+		{
+			name:   "Search.Search",
+			call:   func(app *App) error { _, err := app.Search.Search("go", true); return err },
+			method: http.MethodGet, path: "/api/v2/search", query: url.Values{"q": {"go"}, "resolve": {"true"}},
+		},
+		{
+			name: "Search.Query",
+			call: func(app *App) error {
+				_, err := app.Search.Query("go", &SearchOptions{Type: SearchStatuses, AccountID: "1", Offset: 20})
+				return err
+			},
+			method: http.MethodGet, path: "/api/v2/search",
+			query: url.Values{"q": {"go"}, "type": {"statuses"}, "account_id": {"1"}, "offset": {"20"}},
+		},
+		{
+			name: "Search.ResolveStatus",
+			call: func(app *App) error {
+				_, err := app.Search.ResolveStatus("https://example.com/@bob/1")
+				if !errors.As(err, new(*NotFoundError)) {
+					return fmt.Errorf("got %v, want *NotFoundError", err)
+				}
+				return nil
+			},
+			method: http.MethodGet, path: "/api/v2/search",
+			query: url.Values{"q": {"https://example.com/@bob/1"}, "type": {"statuses"}, "resolve": {"true"}, "limit": {"1"}},
+		},
+		{
+			name: "Search.ResolveAccount",
+			call: func(app *App) error {
+				a, err := app.Search.ResolveAccount("https://example.com/@bob")
+				if err == nil && a.ID != "1" {
+					return fmt.Errorf("got account %q, want 1", a.ID)
+				}
+				return err
+			},
+			method: http.MethodGet, path: "/api/v2/search", res: `{"accounts": [{"id": "1"}]}`,
+			query: url.Values{"q": {"https://example.com/@bob"}, "type": {"accounts"}, "resolve": {"true"}, "limit": {"1"}},
+		},
+		{
+			name:   "Statuses.Get",
+			call:   func(app *App) error { _, err := app.Statuses.Get("1"); return err },
+			method: http.MethodGet, path: "/api/v1/statuses/1",
+		},
+		{
+			name:   "Statuses.Context",
+			call:   func(app *App) error { _, err := app.Statuses.Context("1"); return err },
+			method: http.MethodGet, path: "/api/v1/statuses/1/context",
+		},
+		{
+			name:   "Statuses.Card",
+			call:   func(app *App) error { _, err := app.Statuses.Card("1"); return err },
+			method: http.MethodGet, path: "/api/v1/statuses/1/card",
+		},
+		{
+			name:   "Statuses.Reblogs",
+			call:   func(app *App) error { _, err := app.Statuses.Reblogs("1"); return err },
+			method: http.MethodGet, path: "/api/v1/statuses/1/reblogged_by", res: "[]",
+		},
+		{
+			name:   "Statuses.Favourites",
+			call:   func(app *App) error { _, err := app.Statuses.Favourites("1"); return err },
+			method: http.MethodGet, path: "/api/v1/statuses/1/favourited_by", res: "[]",
+		},
+		{
+			name: "Statuses.Update",
+			call: func(app *App) error {
+				_, err := app.Statuses.Update("hello", &PostOptions{InReplyToID: "1", MediaIDs: []string{"2"}, Visibility: VisibilityUnlisted})
+				return err
+			},
+			method: http.MethodPost, path: "/api/v1/statuses", body: "json",
+			json: `{"status": "hello", "in_reply_to_id": "1", "media_ids": ["2"], "visibility": "unlisted"}`,
+		},
+		{
+			name: "Statuses.Schedule",
+			call: func(app *App) error {
+				at := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+				s, err := app.Statuses.Schedule("later", &PostOptions{ScheduledAt: &at})
+				if err == nil && (s.ID != "1" || s.Params.Text != "later") {
+					return fmt.Errorf("got %+v, want scheduled status 1", s)
+				}
+				return err
+			},
+			method: http.MethodPost, path: "/api/v1/statuses", body: "json",
+			json: `{"status": "later", "scheduled_at": "2030-01-02T03:04:05Z"}`,
+			res:  `{"id": "1", "scheduled_at": "2030-01-02T03:04:05.000Z", "params": {"text": "later"}}`,
+		},
+		{
+			name: "Statuses.Delete",
+			call: func(app *App) error {
+				s, err := app.Statuses.Delete("1")
+				if err == nil && s.Text != "hello" {
+					return fmt.Errorf("got text %q, want %q", s.Text, "hello")
+				}
+				return err
+			},
+			method: http.MethodDelete, path: "/api/v1/statuses/1", res: `{"id": "1", "text": "hello"}`,
+		},
+		{
+			name:   "Statuses.Reblog",
+			call:   func(app *App) error { _, err := app.Statuses.Reblog("1"); return err },
+			method: http.MethodPost, path: "/api/v1/statuses/1/reblog",
+		},
+		{
+			name:   "Statuses.Unreblog",
+			call:   func(app *App) error { _, err := app.Statuses.Unreblog("1"); return err },
+			method: http.MethodPost, path: "/api/v1/statuses/1/unreblog",
+		},
+		{
+			name:   "Statuses.Favourite",
+			call:   func(app *App) error { _, err := app.Statuses.Favourite("1"); return err },
+			method: http.MethodPost, path: "/api/v1/statuses/1/favourite",
+		},
+		{
+			name:   "Statuses.Unfavourite",
+			call:   func(app *App) error { _, err := app.Statuses.Unfavourite("1"); return err },
+			method: http.MethodPost, path: "/api/v1/statuses/1/unfavourite",
+		},
+		{
+			name:   "Suggestions.Get",
+			call:   func(app *App) error { _, err := app.Suggestions.Get(10); return err },
+			method: http.MethodGet, path: "/api/v2/suggestions", res: "[]", query: url.Values{"limit": {"10"}},
+		},
+		{
+			name:   "Suggestions.Remove",
+			call:   func(app *App) error { return app.Suggestions.Remove("1") },
+			method: http.MethodDelete, path: "/api/v1/suggestions/1",
+		},
+		{
+			name:   "Tags.Get",
+			call:   func(app *App) error { _, err := app.Tags.Get("golang"); return err },
+			method: http.MethodGet, path: "/api/v1/tags/golang",
+		},
+		{
+			name:   "Tags.Follow",
+			call:   func(app *App) error { _, err := app.Tags.Follow("golang"); return err },
+			method: http.MethodPost, path: "/api/v1/tags/golang/follow",
+		},
+		{
+			name:   "Tags.Unfollow",
+			call:   func(app *App) error { _, err := app.Tags.Unfollow("golang"); return err },
+			method: http.MethodPost, path: "/api/v1/tags/golang/unfollow",
+		},
+		{
+			name:   "Tags.Followed",
+			call:   func(app *App) error { _, _, err := app.Tags.Followed(nil); return err },
+			method: http.MethodGet, path: "/api/v1/followed_tags", res: "[]",
+		},
+		{
+			name:   "Timelines.Home",
+			call:   func(app *App) error { _, err := app.Timelines.Home(&Pagination{SinceID: "1"}); return err },
+			method: http.MethodGet, path: "/api/v1/timelines/home", res: "[]", query: url.Values{"since_id": {"1"}},
+		},
+		{
+			name: "Timelines.Public",
+			call: func(app *App) error {
+				_, err := app.Timelines.Public(&TimelineOptions{Pagination: Pagination{Limit: 5}, OnlyMedia: true})
+				return err
+			},
+			method: http.MethodGet, path: "/api/v1/timelines/public", res: "[]",
+			query: url.Values{"limit": {"5"}, "only_media": {"true"}},
+		},
+		{
+			name:   "Timelines.Local",
+			call:   func(app *App) error { _, err := app.Timelines.Local(nil); return err },
+			method: http.MethodGet, path: "/api/v1/timelines/public", res: "[]", query: url.Values{"local": {"true"}},
+		},
+		{
+			name:   "Timelines.Remote",
+			call:   func(app *App) error { _, err := app.Timelines.Remote(&Pagination{MaxID: "9"}); return err },
+			method: http.MethodGet, path: "/api/v1/timelines/public", res: "[]", query: url.Values{"remote": {"true"}, "max_id": {"9"}},
+		},
+		{
+			name: "Timelines.Hashtag",
+			call: func(app *App) error {
+				_, err := app.Timelines.Hashtag("go", &HashtagOptions{Any: []string{"golang"}, None: []string{"rust"}})
+				return err
+			},
+			method: http.MethodGet, path: "/api/v1/timelines/tag/go", res: "[]",
+			query: url.Values{"any[]": {"golang"}, "none[]": {"rust"}},
+		},
+		{
+			name:   "Timelines.Link",
+			call:   func(app *App) error { _, err := app.Timelines.Link("https://example.com/", nil); return err },
+			method: http.MethodGet, path: "/api/v1/timelines/link", res: "[]", query: url.Values{"url": {"https://example.com/"}},
+		},
+		{
+			name:   "Timelines.List",
+			call:   func(app *App) error { _, err := app.Timelines.List("1", nil); return err },
+			method: http.MethodGet, path: "/api/v1/timelines/list/1", res: "[]",
+		},
+		{
+			name:   "Trends.Tags",
+			call:   func(app *App) error { _, err := app.Trends.Tags(10, 20); return err },
+			method: http.MethodGet, path: "/api/v1/trends/tags", res: "[]", query: url.Values{"limit": {"10"}, "offset": {"20"}},
+		},
+		{
+			name:   "Trends.Statuses",
+			call:   func(app *App) error { _, err := app.Trends.Statuses(0, 0); return err },
+			method: http.MethodGet, path: "/api/v1/trends/statuses", res: "[]",
+		},
+		{
+			name:   "Trends.Links",
+			call:   func(app *App) error { _, err := app.Trends.Links(0, 0); return err },
+			method: http.MethodGet, path: "/api/v1/trends/links", res: "[]",
+		},
+		{
+			name:   "App.Revoke",
+			call:   func(app *App) error { return app.Revoke("old") },
+			method: http.MethodPost, path: "/oauth/revoke", body: "form",
+			form: url.Values{"client_id": {"id"}, "client_secret": {"secret"}, "token": {"old"}},
+		},
+		{
+			name:   "App.AppToken",
+			call:   func(app *App) error { _, err := app.AppToken(); return err },
+			method: http.MethodPost, path: "/oauth/token", body: "form",
+			form: url.Values{"grant_type": {"client_credentials"}, "client_id": {"id"}, "client_secret": {"secret"}, "redirect_uri": {""}, "scope": {""}},
+		},
+		{
+			name:   "App.Logout",
+			call:   func(app *App) error { return app.Logout() },
+			method: http.MethodPost, path: "/oauth/revoke", body: "form",
+			form: url.Values{"client_id": {"id"}, "client_secret": {"secret"}, "token": {"token"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res := test.res
+			if res == "" {
+				res = "{}"
+			}
+			srv, app := newFakeServer(t, response{http.StatusOK, res})
+			if err := test.call(app); err != nil {
+				t.Fatal(err)
+			}
+
+			req := srv.last(t)
+			if req.Method != test.method {
+				t.Errorf("method: got %s, want %s", req.Method, test.method)
+			}
+			if req.Path != test.path {
+				t.Errorf("path: got %s, want %s", req.Path, test.path)
+			}
+			if len(req.Query)+len(test.query) > 0 && !reflect.DeepEqual(req.Query, test.query) {
+				t.Errorf("query: got %v, want %v", req.Query, test.query)
+			}
+			if got := req.Header.Get("Authorization"); got != "Bearer token" {
+				t.Errorf("authorization: got %q", got)
+			}
+			checkBody(t, req, test.body, test.form, test.json)
+		})
+	}
+}
+
+// checkBody checks the content type and body of a request.
+func checkBody(t *testing.T, req request, typ string, form url.Values, want string) {
+	t.Helper()
+	mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	switch typ {
+	case "":
+		if mediaType != "" || len(req.Body) > 0 {
+			t.Errorf("body: got %q of type %q, want none", req.Body, mediaType)
+		}
+	case "form", "multipart":
+		wantType := map[string]string{"form": "application/x-www-form-urlencoded", "multipart": "multipart/form-data"}[typ]
+		if mediaType != wantType {
+			t.Errorf("content type: got %q, want %q", mediaType, wantType)
+		}
+		if form != nil && !reflect.DeepEqual(req.Form, form) {
+			t.Errorf("form: got %v, want %v", req.Form, form)
+		}
+	case "json":
+		if mediaType != "application/json" {
+			t.Errorf("content type: got %q, want %q", mediaType, "application/json")
+		}
+		var got interface{}
+		if err := json.Unmarshal(req.Body, &got); err != nil {
+			t.Fatalf("could not decode body %q: %v", req.Body, err)
+		}
+		if want == "" {
+			return
+		}
+		var exp interface{}
+		if err := json.Unmarshal([]byte(want), &exp); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, exp) {
+			t.Errorf("body: got %s, want %s", req.Body, want)
+		}
+	}
+}
+
+func TestUpdateScheduled(t *testing.T) {
+	srv, app := newFakeServer(t)
+	at := time.Now().Add(time.Hour)
+	if _, err := app.Statuses.Update("later", &PostOptions{ScheduledAt: &at}); err == nil {
+		t.Error("got no error posting a scheduled status using Update")
+	}
+	if _, err := app.Statuses.Schedule("now", nil); err == nil {
+		t.Error("got no error scheduling a status without ScheduledAt")
+	}
+	if len(srv.requests) > 0 {
+		t.Errorf("got %d requests, want none", len(srv.requests))
+	}
+}
+
+// fakeStore records deleted tokens.
+type fakeStore struct {
+	deleted []string
+	err     error
+}
+
+func (store *fakeStore) Delete(token string) error {
+	store.deleted = append(store.deleted, token)
+	return store.err
+}
+
+func TestLogout(t *testing.T) {
+	srv, app := newFakeServer(t)
+	store := &fakeStore{}
+	app.Store = store
+	if err := app.Logout(); err != nil {
+		t.Fatal(err)
+	}
+	if got := srv.last(t).Form.Get("token"); got != "token" {
+		t.Errorf("revoked %q, want token", got)
+	}
+	if len(store.deleted) != 1 || store.deleted[0] != "token" {
+		t.Errorf("deleted %v, want [token]", store.deleted)
+	}
+	if app.API.AccessToken != "" {
+		t.Errorf("got token %q, want none", app.API.AccessToken)
+	}
+}
+
+func TestLogoutErrors(t *testing.T) {
+	// The token is kept if it could not be revoked.
+	_, app := newFakeServer(t, response{http.StatusUnauthorized, `{"error": "unauthorized_client"}`})
+	store := &fakeStore{}
+	app.Store = store
+	if err := app.Logout(); err == nil {
+		t.Error("got no error, want revocation to fail")
+	}
+	if len(store.deleted) != 0 || app.API.AccessToken != "token" {
+		t.Errorf("deleted %v and kept %q, want nothing deleted and the token kept", store.deleted, app.API.AccessToken)
+	}
+
+	// The token is kept if the store fails.
+	_, app = newFakeServer(t)
+	storeErr := errors.New("disk full")
+	app.Store = &fakeStore{err: storeErr}
+	if err := app.Logout(); !errors.Is(err, storeErr) {
+		t.Errorf("got %v, want %v", err, storeErr)
+	}
+	if app.API.AccessToken != "token" {
+		t.Errorf("got token %q, want it kept", app.API.AccessToken)
+	}
+}
@@ -5,7 +5,7 @@ type Mutes struct {
 	api *API
 }
 
-// Get returns an attachment that can be used when creating a status.
+// Get returns an slice of accounts muted by the authenticated user.
 func (mutes Mutes) Get() ([]Account, error) {
 	a := []Account{}
 	return a, mutes.api.Get("mutes", nil, &a)
@@ -0,0 +1,112 @@
+package mastodon
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// GroupedNotificationOptions holds the params to filter grouped
+// notifications. Empty values use the server's defaults.
+type GroupedNotificationOptions struct {
+	NotificationOptions
+	GroupedTypes    []NotificationType // Only group notifications of these types, e.g. favourites and reblogs
+	ExpandAccounts  string             // Either "full" or "partial_avatars" to return PartialAccounts
+	IncludeFiltered bool               // Include notifications filtered by the notification policy
+}
+
+func (opts GroupedNotificationOptions) values() url.Values {
+	v := opts.NotificationOptions.values()
+	for _, t := range opts.GroupedTypes {
+		v.Add("grouped_types[]", string(t))
+	}
+	if opts.ExpandAccounts != "" {
+		v.Set("expand_accounts", opts.ExpandAccounts)
+	}
+	if opts.IncludeFiltered {
+		v.Set("include_filtered", strconv.FormatBool(opts.IncludeFiltered))
+	}
+	return v
+}
+
+// Groups returns grouped notifications for the authenticated user. opts may
+// be nil.
+func (notifications Notifications) Groups(opts *GroupedNotificationOptions) (GroupedNotifications, error) {
+	g := GroupedNotifications{}
+	v := url.Values{}
+	if opts != nil {
+		v = opts.values()
+	}
+	return g, notifications.api.withPrefix("/api/v2/").Get("notifications", v, &g)
+}
+
+// Group returns a single notification group.
+func (notifications Notifications) Group(key string) (GroupedNotifications, error) {
+	g := GroupedNotifications{}
+	end := fmt.Sprintf("notifications/%s", url.PathEscape(key))
+	return g, notifications.api.withPrefix("/api/v2/").Get(end, nil, &g)
+}
+
+// DismissGroup deletes all notifications of a group.
+func (notifications Notifications) DismissGroup(key string) error {
+	end := fmt.Sprintf("notifications/%s/dismiss", url.PathEscape(key))
+	return notifications.api.withPrefix("/api/v2/").Post(end, nil, nil)
+}
+
+// GroupAccounts returns all accounts of a notification group.
+func (notifications Notifications) GroupAccounts(key string) ([]Account, error) {
+	a := []Account{}
+	end := fmt.Sprintf("notifications/%s/accounts", url.PathEscape(key))
+	return a, notifications.api.withPrefix("/api/v2/").Get(end, nil, &a)
+}
+
+// HydratedNotificationGroup is a notification group with its accounts and
+// status resolved.
+type HydratedNotificationGroup struct {
+	NotificationGroup
+	Accounts []Account // The sample accounts of the group, most recent first
+	Status   *Status   // The Status associated with the group, if applicable
+}
+
+// Hydrate resolves the accounts and statuses the groups refer to using the
+// side tables of the response. Partial accounts are converted to accounts
+// holding only the fields they contain.
+func (g GroupedNotifications) Hydrate() []HydratedNotificationGroup {
+	accs := map[string]Account{}
+	for _, p := range g.PartialAccounts {
+		accs[p.ID] = p.account()
+	}
+	for _, a := range g.Accounts {
+		accs[a.ID] = a
+	}
+	statuses := map[string]Status{}
+	for _, s := range g.Statuses {
+		statuses[s.ID] = s
+	}
+
+	groups := []HydratedNotificationGroup{}
+	for _, group := range g.NotificationGroups {
+		h := HydratedNotificationGroup{NotificationGroup: group}
+		for _, id := range group.SampleAccountIDs {
+			if a, ok := accs[id]; ok {
+				h.Accounts = append(h.Accounts, a)
+			}
+		}
+		if s, ok := statuses[group.StatusID]; ok {
+			h.Status = &s
+		}
+		groups = append(groups, h)
+	}
+	return groups
+}
+
+func (p PartialAccount) account() Account {
+	return Account{
+		ID:     p.ID,
+		Acct:   p.Acct,
+		URL:    p.URL,
+		Avatar: p.Avatar,
+		Locked: p.Locked,
+		Bot:    p.Bot,
+	}
+}
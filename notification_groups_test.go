@@ -0,0 +1,45 @@
+package mastodon
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestHydrate(t *testing.T) {
+	_, app := newFakeServer(t, response{http.StatusOK, `{
+		"accounts": [{"id": "1", "acct": "alice", "display_name": "Alice"}],
+		"partial_accounts": [{"id": "1", "acct": "alice"}, {"id": "2", "acct": "bob@example.com", "bot": true}],
+		"statuses": [{"id": "10", "content": "hello"}],
+		"notification_groups": [
+			{"group_key": "favourite-10", "type": "favourite", "sample_account_ids": ["1", "3", "2"], "status_id": "10"},
+			{"group_key": "ungrouped-5", "type": "follow", "sample_account_ids": ["2"]}
+		]
+	}`})
+	g, err := app.Notifications.Groups(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	groups := g.Hydrate()
+	if len(groups) != 2 {
+		t.Fatalf("got %d groups, want 2", len(groups))
+	}
+
+	fav := groups[0]
+	if fav.GroupKey != "favourite-10" || len(fav.Accounts) != 2 {
+		t.Fatalf("got %s with %d accounts, want favourite-10 with 2", fav.GroupKey, len(fav.Accounts))
+	}
+	// Full accounts take precedence over partial ones; unknown IDs are skipped.
+	if a := fav.Accounts[0]; a.ID != "1" || a.DisplayName != "Alice" {
+		t.Errorf("got account %s named %q, want full account 1", a.ID, a.DisplayName)
+	}
+	if a := fav.Accounts[1]; a.ID != "2" || a.Acct != "bob@example.com" || !a.Bot {
+		t.Errorf("got account %+v, want partial account 2", a)
+	}
+	if fav.Status == nil || fav.Status.ID != "10" || fav.Status.Content != "hello" {
+		t.Errorf("got status %v, want 10", fav.Status)
+	}
+
+	if follow := groups[1]; follow.Status != nil || len(follow.Accounts) != 1 {
+		t.Errorf("got status %v and %d accounts, want no status and 1 account", follow.Status, len(follow.Accounts))
+	}
+}
@@ -0,0 +1,91 @@
+package mastodon
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// PolicyAction is the way notifications matching a policy are handled.
+type PolicyAction string
+
+// Ways to handle notifications matching a policy.
+const (
+	PolicyAccept PolicyAction = "accept" // Show notifications
+	PolicyFilter PolicyAction = "filter" // Move notifications to notification requests
+	PolicyDrop   PolicyAction = "drop"   // Discard notifications
+)
+
+// Policy returns the notification policy of the authenticated user.
+func (notifications Notifications) Policy() (NotificationPolicy, error) {
+	p := NotificationPolicy{}
+	return p, notifications.api.withPrefix("/api/v2/").Get("notifications/policy", nil, &p)
+}
+
+// UpdatePolicy updates the notification policy of the authenticated user and
+// returns it. Empty actions are left unchanged.
+func (notifications Notifications) UpdatePolicy(p NotificationPolicy) (NotificationPolicy, error) {
+	actions := map[string]PolicyAction{
+		"for_not_following":    p.ForNotFollowing,
+		"for_not_followers":    p.ForNotFollowers,
+		"for_new_accounts":     p.ForNewAccounts,
+		"for_private_mentions": p.ForPrivateMentions,
+		"for_limited_accounts": p.ForLimitedAccounts,
+	}
+	body := map[string]PolicyAction{}
+	for key, action := range actions {
+		if action != "" {
+			body[key] = action
+		}
+	}
+	updated := NotificationPolicy{}
+	return updated, notifications.api.withPrefix("/api/v2/").PatchJSON("notifications/policy", body, &updated)
+}
+
+// Requests returns notification requests, i.e. filtered notifications
+// grouped by account. p may be nil.
+func (notifications Notifications) Requests(p *Pagination) ([]NotificationRequest, error) {
+	r := []NotificationRequest{}
+	return r, notifications.api.Get("notifications/requests", paginationValues(p), &r)
+}
+
+// Request returns a single notification request.
+func (notifications Notifications) Request(id string) (NotificationRequest, error) {
+	r := NotificationRequest{}
+	end := fmt.Sprintf("notifications/requests/%s", id)
+	return r, notifications.api.Get(end, nil, &r)
+}
+
+// AcceptRequest accepts a notification request, moving its notifications to
+// the regular notifications.
+func (notifications Notifications) AcceptRequest(id string) error {
+	end := fmt.Sprintf("notifications/requests/%s/accept", id)
+	return notifications.api.Post(end, nil, nil)
+}
+
+// DismissRequest dismisses a notification request, dropping its
+// notifications.
+func (notifications Notifications) DismissRequest(id string) error {
+	end := fmt.Sprintf("notifications/requests/%s/dismiss", id)
+	return notifications.api.Post(end, nil, nil)
+}
+
+// AcceptRequests accepts multiple notification requests at once.
+func (notifications Notifications) AcceptRequests(ids ...string) error {
+	v := url.Values{"id[]": ids}
+	return notifications.api.Post("notifications/requests/accept", v, nil)
+}
+
+// DismissRequests dismisses multiple notification requests at once.
+func (notifications Notifications) DismissRequests(ids ...string) error {
+	v := url.Values{"id[]": ids}
+	return notifications.api.Post("notifications/requests/dismiss", v, nil)
+}
+
+// RequestsMerged reports whether notifications of accepted requests have been
+// merged into the regular notifications. Merging happens asynchronously.
+func (notifications Notifications) RequestsMerged() (bool, error) {
+	m := struct {
+		Merged bool `json:"merged"`
+	}{}
+	return m.Merged, notifications.api.Get("notifications/requests/merged", nil, &m)
+}
@@ -1,16 +1,67 @@
 package mastodon
 
-import "fmt"
+import (
+	"fmt"
+	"net/url"
+)
 
 // Notifications implements methods under /notifications.
 type Notifications struct {
 	api *API
 }
 
-// Get returns a list of notifications for the authenticated user.
-func (notifications Notifications) Get() ([]Notification, error) {
+// NotificationType is the type of a notification.
+type NotificationType string
+
+// Types of notifications.
+const (
+	NotificationMention             NotificationType = "mention"               // Someone mentioned the user
+	NotificationStatus              NotificationType = "status"                // Someone the user enabled notifications for posted a status
+	NotificationReblog              NotificationType = "reblog"                // Someone reblogged a status of the user
+	NotificationFollow              NotificationType = "follow"                // Someone followed the user
+	NotificationFollowRequest       NotificationType = "follow_request"        // Someone requested to follow the user
+	NotificationFavourite           NotificationType = "favourite"             // Someone favourited a status of the user
+	NotificationPoll                NotificationType = "poll"                  // A poll the user voted in or created has ended
+	NotificationUpdate              NotificationType = "update"                // A status the user interacted with has been edited
+	NotificationAdminSignUp         NotificationType = "admin.sign_up"         // Someone signed up (moderators only)
+	NotificationAdminReport         NotificationType = "admin.report"          // A new report has been filed (moderators only)
+	NotificationSeveredRelationship NotificationType = "severed_relationships" // Relationships have been severed by a moderation action
+	NotificationModerationWarning   NotificationType = "moderation_warning"    // A moderator has taken action against the user
+)
+
+// NotificationOptions holds the params to filter notifications. Empty values
+// use the server's defaults.
+type NotificationOptions struct {
+	Pagination
+	Types        []NotificationType // Only return notifications of these types
+	ExcludeTypes []NotificationType // Skip notifications of these types
+	AccountID    string             // Only return notifications received from this account
+}
+
+func (opts NotificationOptions) values() url.Values {
+	v := url.Values{}
+	opts.Pagination.setValues(v)
+	for _, t := range opts.Types {
+		v.Add("types[]", string(t))
+	}
+	for _, t := range opts.ExcludeTypes {
+		v.Add("exclude_types[]", string(t))
+	}
+	if opts.AccountID != "" {
+		v.Set("account_id", opts.AccountID)
+	}
+	return v
+}
+
+// Get returns a list of notifications for the authenticated user. opts may
+// be nil.
+func (notifications Notifications) Get(opts *NotificationOptions) ([]Notification, error) {
 	n := []Notification{}
-	return n, notifications.api.Get("notifications", nil, &n)
+	v := url.Values{}
+	if opts != nil {
+		v = opts.values()
+	}
+	return n, notifications.api.Get("notifications", v, &n)
 }
 
 // GetSingle returns the notification.
@@ -23,5 +74,25 @@ func (notifications Notifications) GetSingle(id string) (Notification, error) {
 // Clear deletes all notifications from the Mastodon server for the
 // authenticated user.
 func (notifications Notifications) Clear() error {
-	return notifications.api.Get("notifications/clear", nil, nil)
+	return notifications.api.Post("notifications/clear", nil, nil)
+}
+
+// Dismiss deletes a single notification from the Mastodon server for the
+// authenticated user.
+func (notifications Notifications) Dismiss(id string) error {
+	end := fmt.Sprintf("notifications/%s/dismiss", id)
+	return notifications.api.Post(end, nil, nil)
+}
+
+// UnreadCount returns the number of unread notifications, as far as the
+// server knows the read position. opts may be nil.
+func (notifications Notifications) UnreadCount(opts *NotificationOptions) (int, error) {
+	c := struct {
+		Count int `json:"count"`
+	}{}
+	v := url.Values{}
+	if opts != nil {
+		v = opts.values()
+	}
+	return c.Count, notifications.api.Get("notifications/unread_count", v, &c)
 }
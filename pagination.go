@@ -0,0 +1,92 @@
+package mastodon
+
+import (
+	"net/url"
+	"regexp"
+	"strconv"
+)
+
+var linkRegexp = regexp.MustCompile(`<([^>]*)>\s*;\s*rel="?([^";,]+)"?`)
+
+// Pagination holds the params to page through lists. Empty values use the
+// server's defaults.
+type Pagination struct {
+	MaxID   string // Return results older than this ID
+	SinceID string // Return results newer than this ID
+	MinID   string // Return results immediately newer than this ID
+	Limit   int    // Maximum number of results to return
+}
+
+// Page holds the params to request the pages next to a list, as linked by
+// the server. Some lists can only be paged through this way as their
+// results are not ordered by ID.
+type Page struct {
+	Next *Pagination // Params of the page of older results; nil if there is none
+	Prev *Pagination // Params of the page of newer results; nil if there is none
+}
+
+// parseLink parses the pages linked in a Link header.
+func parseLink(h string) Page {
+	page := Page{}
+	for _, m := range linkRegexp.FindAllStringSubmatch(h, -1) {
+		u, err := url.Parse(m[1])
+		if err != nil {
+			continue
+		}
+		q := u.Query()
+		p := &Pagination{
+			MaxID:   q.Get("max_id"),
+			SinceID: q.Get("since_id"),
+			MinID:   q.Get("min_id"),
+		}
+		p.Limit, _ = strconv.Atoi(q.Get("limit"))
+		switch m[2] {
+		case "next":
+			page.Next = p
+		case "prev":
+			page.Prev = p
+		}
+	}
+	return page
+}
+
+// setValues adds the params to v.
+func (p Pagination) setValues(v url.Values) {
+	if p.MaxID != "" {
+		v.Set("max_id", p.MaxID)
+	}
+	if p.SinceID != "" {
+		v.Set("since_id", p.SinceID)
+	}
+	if p.MinID != "" {
+		v.Set("min_id", p.MinID)
+	}
+	if p.Limit > 0 {
+		v.Set("limit", strconv.Itoa(p.Limit))
+	}
+}
+
+// compareIDs compares two numeric IDs, which may exceed the range of int64,
+// and returns -1, 0 or 1.
+func compareIDs(a, b string) int {
+	switch {
+	case len(a) < len(b):
+		return -1
+	case len(a) > len(b):
+		return 1
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// paginationValues returns the params of p, which may be nil.
+func paginationValues(p *Pagination) url.Values {
+	v := url.Values{}
+	if p != nil {
+		p.setValues(v)
+	}
+	return v
+}
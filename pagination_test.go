@@ -0,0 +1,52 @@
+package mastodon
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestParseLink(t *testing.T) {
+	tests := []struct {
+		header string
+		want   Page
+	}{
+		{"", Page{}},
+		{
+			`<https://example.com/api/v1/followed_tags?limit=2&max_id=123>; rel="next", <https://example.com/api/v1/followed_tags?min_id=456>; rel="prev"`,
+			Page{Next: &Pagination{MaxID: "123", Limit: 2}, Prev: &Pagination{MinID: "456"}},
+		},
+		{
+			`<https://example.com/api/v1/blocks?since_id=7>;rel=prev`,
+			Page{Prev: &Pagination{SinceID: "7"}},
+		},
+	}
+	for _, test := range tests {
+		if got := parseLink(test.header); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("parseLink(%q) = %+v, want %+v", test.header, got, test.want)
+		}
+	}
+}
+
+func TestTagsFollowedPage(t *testing.T) {
+	srv, app := newFakeServer(t, response{http.StatusOK, `[{"name": "golang"}]`})
+	srv.header = http.Header{"Link": {`<` + srv.URL + `/api/v1/followed_tags?max_id=42>; rel="next"`}}
+
+	tags, page, err := app.Tags.Followed(&Pagination{Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tags) != 1 || tags[0].Name != "golang" {
+		t.Errorf("got %v, want golang", tags)
+	}
+	if page.Next == nil || page.Next.MaxID != "42" || page.Prev != nil {
+		t.Fatalf("got %+v, want next page at 42", page)
+	}
+
+	if _, _, err := app.Tags.Followed(page.Next); err != nil {
+		t.Fatal(err)
+	}
+	if got := srv.last(t).Query.Get("max_id"); got != "42" {
+		t.Errorf("got max_id %q, want 42", got)
+	}
+}
@@ -0,0 +1,77 @@
+package mastodon
+
+// Push implements methods under /push.
+type Push struct {
+	api *API
+}
+
+// PushPolicy selects the accounts whose notifications are pushed.
+type PushPolicy string
+
+// Policies of push subscriptions.
+const (
+	PushAll      PushPolicy = "all"      // Notifications from all accounts
+	PushFollowed PushPolicy = "followed" // Notifications from accounts the user follows
+	PushFollower PushPolicy = "follower" // Notifications from accounts following the user
+	PushNone     PushPolicy = "none"     // No notifications
+)
+
+// PushAlerts holds the types of notifications pushed to a subscription.
+type PushAlerts struct {
+	Mention       bool `json:"mention"`
+	Status        bool `json:"status"`
+	Reblog        bool `json:"reblog"`
+	Follow        bool `json:"follow"`
+	FollowRequest bool `json:"follow_request"`
+	Favourite     bool `json:"favourite"`
+	Poll          bool `json:"poll"`
+	Update        bool `json:"update"`
+	AdminSignUp   bool `json:"admin.sign_up"`
+	AdminReport   bool `json:"admin.report"`
+}
+
+// pushData holds the params of a subscription.
+type pushData struct {
+	Alerts PushAlerts `json:"alerts"`
+	Policy PushPolicy `json:"policy,omitempty"`
+}
+
+// Subscribe subscribes to Web Push notifications sent to endpoint and
+// encrypted for keys. The policy defaults to PushAll if empty. An existing
+// subscription of the token is replaced.
+func (push Push) Subscribe(endpoint string, keys *PushKeys, alerts PushAlerts, policy PushPolicy) (PushSubscription, error) {
+	s := PushSubscription{}
+	body := map[string]interface{}{
+		"subscription": map[string]interface{}{
+			"endpoint": endpoint,
+			"keys": map[string]string{
+				"p256dh": keys.PublicKey(),
+				"auth":   keys.AuthSecret(),
+			},
+			"standard": true,
+		},
+		"data": pushData{Alerts: alerts, Policy: policy},
+	}
+	return s, push.api.PostJSON("push/subscription", body, &s)
+}
+
+// Get returns the subscription of the token.
+func (push Push) Get() (PushSubscription, error) {
+	s := PushSubscription{}
+	return s, push.api.Get("push/subscription", nil, &s)
+}
+
+// Update changes the alerts and policy of the subscription of the token.
+// The policy is left unchanged if empty.
+func (push Push) Update(alerts PushAlerts, policy PushPolicy) (PushSubscription, error) {
+	s := PushSubscription{}
+	body := map[string]interface{}{
+		"data": pushData{Alerts: alerts, Policy: policy},
+	}
+	return s, push.api.PutJSON("push/subscription", body, &s)
+}
+
+// Unsubscribe deletes the subscription of the token.
+func (push Push) Unsubscribe() error {
+	return push.api.Delete("push/subscription", nil, nil)
+}
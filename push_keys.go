@@ -0,0 +1,263 @@
+package mastodon
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// PushKeys holds the keys to decrypt Web Push messages. Both keys have to be
+// stored to decrypt messages after a restart, see LoadPushKeys.
+type PushKeys struct {
+	Private *ecdh.PrivateKey // The P-256 key agreed on with the server
+	Auth    []byte           // The shared 16 byte authentication secret
+}
+
+// NewPushKeys generates new keys.
+func NewPushKeys() (*PushKeys, error) {
+	private, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("could not generate private key: %v", err)
+	}
+	auth := make([]byte, 16)
+	if _, err := rand.Read(auth); err != nil {
+		return nil, fmt.Errorf("could not generate auth secret: %v", err)
+	}
+	return &PushKeys{Private: private, Auth: auth}, nil
+}
+
+// LoadPushKeys restores keys from the bytes of the private key and the auth
+// secret.
+func LoadPushKeys(private, auth []byte) (*PushKeys, error) {
+	key, err := ecdh.P256().NewPrivateKey(private)
+	if err != nil {
+		return nil, fmt.Errorf("could not load private key: %v", err)
+	}
+	if len(auth) != 16 {
+		return nil, fmt.Errorf("could not load auth secret: got %d bytes, want 16", len(auth))
+	}
+	return &PushKeys{Private: key, Auth: auth}, nil
+}
+
+// PublicKey returns the public key, encoded as sent to the server.
+func (keys *PushKeys) PublicKey() string {
+	return base64.RawURLEncoding.EncodeToString(keys.Private.PublicKey().Bytes())
+}
+
+// AuthSecret returns the auth secret, encoded as sent to the server.
+func (keys *PushKeys) AuthSecret() string {
+	return base64.RawURLEncoding.EncodeToString(keys.Auth)
+}
+
+// DecryptNotification decrypts the body of a Web Push request and decodes
+// the notification.
+func (keys *PushKeys) DecryptNotification(header http.Header, body []byte) (PushNotification, error) {
+	n := PushNotification{}
+	plain, err := keys.Decrypt(header, body)
+	if err != nil {
+		return n, err
+	}
+	if err := json.Unmarshal(plain, &n); err != nil {
+		return n, fmt.Errorf("could not decode push notification: %v", err)
+	}
+	return n, nil
+}
+
+// Decrypt decrypts the body of a Web Push request. Both the "aes128gcm"
+// (RFC 8291) and the legacy "aesgcm" content encodings are supported.
+func (keys *PushKeys) Decrypt(header http.Header, body []byte) ([]byte, error) {
+	switch enc := header.Get("Content-Encoding"); enc {
+	case "aes128gcm":
+		return keys.decryptAES128GCM(body)
+	case "aesgcm":
+		return keys.decryptAESGCM(header, body)
+	default:
+		return nil, fmt.Errorf("unsupported content encoding %q", enc)
+	}
+}
+
+func (keys *PushKeys) decryptAES128GCM(body []byte) ([]byte, error) {
+	if len(body) < 21 {
+		return nil, errors.New("could not decrypt push message: header too short")
+	}
+	salt := body[:16]
+	rs := int(binary.BigEndian.Uint32(body[16:20]))
+	idlen := int(body[20])
+	if len(body) < 21+idlen {
+		return nil, errors.New("could not decrypt push message: header too short")
+	}
+	serverKey := body[21 : 21+idlen]
+	if rs <= 17 {
+		return nil, fmt.Errorf("could not decrypt push message: invalid record size %d", rs)
+	}
+
+	secret, err := keys.sharedSecret(serverKey)
+	if err != nil {
+		return nil, err
+	}
+	info := append([]byte("WebPush: info\x00"), keys.Private.PublicKey().Bytes()...)
+	info = append(info, serverKey...)
+	cek, nonce, err := keys.contentKeys(secret, salt, info, []byte("Content-Encoding: aes128gcm\x00"), []byte("Content-Encoding: nonce\x00"))
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := decryptRecords(cek, nonce, body[21+idlen:], rs)
+	if err != nil {
+		return nil, err
+	}
+	plain := []byte{}
+	for i, r := range records {
+		// Records are padded with zeros, preceded by a delimiter: 2 for the
+		// last record, 1 for all others.
+		r = bytes.TrimRight(r, "\x00")
+		last := i == len(records)-1
+		if len(r) == 0 || (last && r[len(r)-1] != 2) || (!last && r[len(r)-1] != 1) {
+			return nil, errors.New("could not decrypt push message: invalid padding")
+		}
+		plain = append(plain, r[:len(r)-1]...)
+	}
+	return plain, nil
+}
+
+func (keys *PushKeys) decryptAESGCM(header http.Header, body []byte) ([]byte, error) {
+	params := headerParams(header.Get("Encryption"))
+	salt, err := decodeBase64(params["salt"])
+	if err != nil || len(salt) != 16 {
+		return nil, errors.New("could not decrypt push message: invalid salt")
+	}
+	rs := 4096
+	if params["rs"] != "" {
+		if rs, err = strconv.Atoi(params["rs"]); err != nil || rs <= 1 {
+			return nil, errors.New("could not decrypt push message: invalid record size")
+		}
+	}
+	serverKey, err := decodeBase64(headerParams(header.Get("Crypto-Key"))["dh"])
+	if err != nil {
+		return nil, errors.New("could not decrypt push message: invalid server key")
+	}
+
+	secret, err := keys.sharedSecret(serverKey)
+	if err != nil {
+		return nil, err
+	}
+	public := keys.Private.PublicKey().Bytes()
+	context := []byte("P-256\x00")
+	context = binary.BigEndian.AppendUint16(context, uint16(len(public)))
+	context = append(context, public...)
+	context = binary.BigEndian.AppendUint16(context, uint16(len(serverKey)))
+	context = append(context, serverKey...)
+	cek, nonce, err := keys.contentKeys(secret, salt, []byte("Content-Encoding: auth\x00"),
+		append([]byte("Content-Encoding: aesgcm\x00"), context...),
+		append([]byte("Content-Encoding: nonce\x00"), context...))
+	if err != nil {
+		return nil, err
+	}
+
+	// The record size excludes the authentication tag.
+	records, err := decryptRecords(cek, nonce, body, rs+16)
+	if err != nil {
+		return nil, err
+	}
+	plain := []byte{}
+	for _, r := range records {
+		// Records start with the length of the following padding.
+		if len(r) < 2 {
+			return nil, errors.New("could not decrypt push message: invalid padding")
+		}
+		pad := int(binary.BigEndian.Uint16(r))
+		if len(r) < 2+pad {
+			return nil, errors.New("could not decrypt push message: invalid padding")
+		}
+		plain = append(plain, r[2+pad:]...)
+	}
+	return plain, nil
+}
+
+func (keys *PushKeys) sharedSecret(serverKey []byte) ([]byte, error) {
+	public, err := ecdh.P256().NewPublicKey(serverKey)
+	if err != nil {
+		return nil, fmt.Errorf("could not decrypt push message: invalid server key: %v", err)
+	}
+	secret, err := keys.Private.ECDH(public)
+	if err != nil {
+		return nil, fmt.Errorf("could not decrypt push message: %v", err)
+	}
+	return secret, nil
+}
+
+// decryptRecords decrypts ciphertext split into records of size rs. The
+// nonce of each record is derived from its sequence number.
+func decryptRecords(cek, nonce, ciphertext []byte, rs int) ([][]byte, error) {
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, fmt.Errorf("could not decrypt push message: %v", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("could not decrypt push message: %v", err)
+	}
+
+	records := [][]byte{}
+	for seq := uint64(0); len(ciphertext) > 0; seq++ {
+		n := rs
+		if n > len(ciphertext) {
+			n = len(ciphertext)
+		}
+		iv := make([]byte, len(nonce))
+		copy(iv, nonce)
+		binary.BigEndian.PutUint64(iv[4:], binary.BigEndian.Uint64(nonce[4:])^seq)
+		r, err := gcm.Open(nil, iv, ciphertext[:n], nil)
+		if err != nil {
+			return nil, fmt.Errorf("could not decrypt push message: %v", err)
+		}
+		records = append(records, r)
+		ciphertext = ciphertext[n:]
+	}
+	return records, nil
+}
+
+// contentKeys derives the content encryption key and nonce from the shared
+// secret using HKDF. The authentication secret is mixed in first.
+func (keys *PushKeys) contentKeys(secret, salt, authInfo, cekInfo, nonceInfo []byte) (cek, nonce []byte, err error) {
+	ikm, err := hkdf.Key(sha256.New, secret, keys.Auth, string(authInfo), 32)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not decrypt push message: %w", err)
+	}
+	if cek, err = hkdf.Key(sha256.New, ikm, salt, string(cekInfo), 16); err != nil {
+		return nil, nil, fmt.Errorf("could not decrypt push message: %w", err)
+	}
+	if nonce, err = hkdf.Key(sha256.New, ikm, salt, string(nonceInfo), 12); err != nil {
+		return nil, nil, fmt.Errorf("could not decrypt push message: %w", err)
+	}
+	return cek, nonce, nil
+}
+
+// headerParams parses headers like `salt=abc; rs=4096` into a map. Multiple
+// values separated by commas are merged.
+func headerParams(h string) map[string]string {
+	params := map[string]string{}
+	for _, p := range strings.FieldsFunc(h, func(r rune) bool { return r == ';' || r == ',' }) {
+		kv := strings.SplitN(strings.TrimSpace(p), "=", 2)
+		if len(kv) == 2 {
+			params[kv[0]] = strings.Trim(kv[1], `"`)
+		}
+	}
+	return params
+}
+
+func decodeBase64(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+}
@@ -0,0 +1,168 @@
+package mastodon
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/binary"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// loadTestKeys loads base64 encoded keys.
+func loadTestKeys(t *testing.T, private, auth string) *PushKeys {
+	t.Helper()
+	p, err := decodeBase64(private)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, err := decodeBase64(auth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys, err := LoadPushKeys(p, a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return keys
+}
+
+// The example of RFC 8291, Appendix A.
+func rfc8291Vector(t *testing.T) (*PushKeys, http.Header, []byte) {
+	keys := loadTestKeys(t, "q1dXpw3UpT5VOmu_cf_v6ih07Aems3njxI-JWgLcM94", "BTBZMqHH6r4Tts7J_aSIgg")
+	body, err := decodeBase64("DGv6ra1nlYgDCS1FRnbzlwAAEABBBP4z9KsN6nGRTbVYI_c7VJSPQTBtkgcy27ml" +
+		"mlMoZIIgDll6e3vCYLocInmYWAmS6TlzAC8wEqKK6PBru3jl7A_yl95bQpu6cVPT" +
+		"pK4Mqgkf1CXztLVBSt2Ks3oZwbuwXPXLWyouBWLVWGNWQexSgSxsj_Qulcy4a-fN")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return keys, http.Header{"Content-Encoding": {"aes128gcm"}}, body
+}
+
+// The example of draft-ietf-webpush-encryption-04, Appendix A.
+func aesgcmVector(t *testing.T) (*PushKeys, http.Header, []byte) {
+	keys := loadTestKeys(t, "9FWl15_QUQAWDaD3k3l50ZBZQJ4au27F1V4F0uLSD_M", "R29vIGdvbyBnJyBqb29iIQ")
+	body, err := decodeBase64("6nqAQUME8hNqw5J3kl8cpVVJylXKYqZOeseZG8UueKpA")
+	if err != nil {
+		t.Fatal(err)
+	}
+	header := http.Header{
+		"Content-Encoding": {"aesgcm"},
+		"Encryption":       {"salt=lngarbyKfMoi9Z75xYXmkg"},
+		"Crypto-Key":       {"dh=BNoRDbb84JGm8g5Z5CFxurSqsXWJ11ItfXEWYVLE85Y7CYkDjXsIEc4aqxYaQ1G8BqkXCJ6DPpDrWtdWj_mugHU"},
+	}
+	return keys, header, body
+}
+
+func TestDecrypt(t *testing.T) {
+	tests := []struct {
+		name   string
+		vector func(t *testing.T) (*PushKeys, http.Header, []byte)
+		want   string
+	}{
+		{"aes128gcm", rfc8291Vector, "When I grow up, I want to be a watermelon"},
+		{"aesgcm", aesgcmVector, "I am the walrus"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			keys, header, body := test.vector(t)
+			plain, err := keys.Decrypt(header, body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(plain) != test.want {
+				t.Errorf("got %q, want %q", plain, test.want)
+			}
+
+			tampered := append([]byte{}, body...)
+			tampered[len(tampered)-1] ^= 1
+			if _, err := keys.Decrypt(header, tampered); err == nil {
+				t.Error("tampered ciphertext decrypted")
+			}
+
+			other, err := NewPushKeys()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := other.Decrypt(header, body); err == nil {
+				t.Error("decrypted with wrong keys")
+			}
+		})
+	}
+}
+
+func TestDecryptUnsupportedEncoding(t *testing.T) {
+	keys, _, body := rfc8291Vector(t)
+	if _, err := keys.Decrypt(http.Header{"Content-Encoding": {"gzip"}}, body); err == nil {
+		t.Error("got no error for unsupported encoding")
+	}
+}
+
+// encryptAES128GCM encrypts a single record, including its padding, for keys.
+func encryptAES128GCM(t *testing.T, keys *PushKeys, record []byte) []byte {
+	t.Helper()
+	server, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	serverKey := server.PublicKey().Bytes()
+	secret, err := keys.sharedSecret(serverKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	salt := make([]byte, 16)
+	rand.Read(salt)
+	info := append([]byte("WebPush: info\x00"), keys.Private.PublicKey().Bytes()...)
+	info = append(info, serverKey...)
+	cek, nonce, err := keys.contentKeys(secret, salt, info, []byte("Content-Encoding: aes128gcm\x00"), []byte("Content-Encoding: nonce\x00"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body := append(salt, 0, 0, 0, 0, byte(len(serverKey)))
+	binary.BigEndian.PutUint32(body[16:20], 4096)
+	body = append(body, serverKey...)
+	return gcm.Seal(body, nonce, record, nil)
+}
+
+func TestDecryptPadding(t *testing.T) {
+	keys, err := NewPushKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	header := http.Header{"Content-Encoding": {"aes128gcm"}}
+	tests := []struct {
+		record string
+		want   string // Expected plaintext, or an error if empty
+	}{
+		{"hello\x02", "hello"},
+		{"hello\x02\x00\x00\x00", "hello"},
+		{"hello\x01", ""},
+		{"hello\x03", ""},
+		{"\x00\x00\x00", ""},
+	}
+	for _, test := range tests {
+		t.Run(strings.ReplaceAll(test.record, "\x00", "0"), func(t *testing.T) {
+			plain, err := keys.Decrypt(header, encryptAES128GCM(t, keys, []byte(test.record)))
+			if test.want == "" {
+				if err == nil || !strings.Contains(err.Error(), "padding") {
+					t.Errorf("got %q, %v, want padding error", plain, err)
+				}
+				return
+			}
+			if err != nil || string(plain) != test.want {
+				t.Errorf("got %q, %v, want %q", plain, err, test.want)
+			}
+		})
+	}
+}
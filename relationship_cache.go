@@ -0,0 +1,73 @@
+package mastodon
+
+import "sync"
+
+// RelationshipCache caches relationships by account ID. Set it on Accounts
+// to cache the results of Accounts.RelationshipsByID. Entries are dropped
+// when following, blocking or muting the account using Accounts. The zero
+// value is an empty cache.
+type RelationshipCache struct {
+	mu   sync.RWMutex
+	rels map[relationshipKey]Relationship
+}
+
+// relationshipKey identifies a cached relationship. Relationships requested
+// with and without suspended accounts are cached separately.
+type relationshipKey struct {
+	id            string
+	withSuspended bool
+}
+
+// NewRelationshipCache returns an empty cache.
+func NewRelationshipCache() *RelationshipCache {
+	return &RelationshipCache{}
+}
+
+// Get returns the cached relationship to an account.
+func (cache *RelationshipCache) Get(id string, withSuspended bool) (Relationship, bool) {
+	if cache == nil {
+		return Relationship{}, false
+	}
+	cache.mu.RLock()
+	defer cache.mu.RUnlock()
+	rel, ok := cache.rels[relationshipKey{id, withSuspended}]
+	return rel, ok
+}
+
+// Set caches relationships by their account ID.
+func (cache *RelationshipCache) Set(withSuspended bool, rels ...Relationship) {
+	if cache == nil {
+		return
+	}
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if cache.rels == nil {
+		cache.rels = map[relationshipKey]Relationship{}
+	}
+	for _, rel := range rels {
+		cache.rels[relationshipKey{rel.ID, withSuspended}] = rel
+	}
+}
+
+// Invalidate drops the cached relationships to the given accounts.
+func (cache *RelationshipCache) Invalidate(ids ...string) {
+	if cache == nil {
+		return
+	}
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	for _, id := range ids {
+		delete(cache.rels, relationshipKey{id, false})
+		delete(cache.rels, relationshipKey{id, true})
+	}
+}
+
+// Clear drops all cached relationships.
+func (cache *RelationshipCache) Clear() {
+	if cache == nil {
+		return
+	}
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	cache.rels = nil
+}
@@ -0,0 +1,54 @@
+package mastodon
+
+import (
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestRelationshipCacheZeroValue(t *testing.T) {
+	cache := &RelationshipCache{}
+	cache.Set(false, Relationship{ID: "1", Following: true})
+	if rel, ok := cache.Get("1", false); !ok || !rel.Following {
+		t.Errorf("got %v, %t, want cached relationship", rel, ok)
+	}
+	if _, ok := cache.Get("1", true); ok {
+		t.Error("relationship without suspended accounts returned for withSuspended")
+	}
+	cache.Invalidate("1")
+	if _, ok := cache.Get("1", false); ok {
+		t.Error("relationship not invalidated")
+	}
+}
+
+func TestRelationshipsByID(t *testing.T) {
+	srv, app := newFakeServer(t, response{http.StatusOK, `[{"id": "3"}, {"id": "1"}]`})
+	app.Accounts.Cache = NewRelationshipCache()
+	app.Accounts.Cache.Set(false, Relationship{ID: "2"})
+
+	rels, err := app.Accounts.RelationshipsByID([]string{"1", "2", "3"}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := []string{}
+	for _, rel := range rels {
+		ids = append(ids, rel.ID)
+	}
+	if want := []string{"1", "2", "3"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("got %v, want %v", ids, want)
+	}
+	want := url.Values{"id[]": {"1", "3"}, "with_suspended": {"false"}}
+	if got := srv.last(t).Query; !reflect.DeepEqual(got, want) {
+		t.Errorf("query: got %v, want %v", got, want)
+	}
+
+	// Cached entries are not shared with requests including suspended accounts.
+	if _, err := app.Accounts.RelationshipsByID([]string{"2"}, true); err != nil {
+		t.Fatal(err)
+	}
+	want = url.Values{"id[]": {"2"}, "with_suspended": {"true"}}
+	if got := srv.last(t).Query; !reflect.DeepEqual(got, want) {
+		t.Errorf("query: got %v, want %v", got, want)
+	}
+}
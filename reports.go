@@ -10,6 +10,8 @@ type Reports struct {
 }
 
 // Get returns a list of reports made by the authenticated user.
+//
+// Deprecated: The endpoint has been removed in Mastodon 2.5.
 func (reports Reports) Get() ([]Report, error) {
 	r := []Report{}
 	return r, reports.api.Get("reports", nil, &r)
@@ -20,8 +22,10 @@ func (reports Reports) Report(account, status string, comment string) (Report, e
 	r := Report{}
 	v := url.Values{
 		"account_id": {account},
-		"status_ids": {status},
 		"comment":    {comment},
 	}
+	if status != "" {
+		v.Set("status_ids[]", status)
+	}
 	return r, reports.api.Post("reports", v, &r)
 }
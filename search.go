@@ -1,23 +1,120 @@
 package mastodon
 
-import "net/url"
+import (
+	"net/url"
+	"strconv"
+)
 
 // Search implements methods under /search.
 type Search struct {
 	api *API
 }
 
+// SearchType limits a search to one kind of results.
+type SearchType string
+
+// Kinds of search results.
+const (
+	SearchAccounts SearchType = "accounts"
+	SearchHashtags SearchType = "hashtags"
+	SearchStatuses SearchType = "statuses"
+)
+
+// SearchOptions holds the params of a search. Empty values use the server's
+// defaults.
+type SearchOptions struct {
+	Pagination
+	Type              SearchType // Only return results of this kind
+	Resolve           bool       // Fetch remote accounts and statuses if q is an URL or handle
+	Following         bool       // Only return accounts the user is following
+	AccountID         string     // Only return statuses posted by this account
+	ExcludeUnreviewed bool       // Skip hashtags that have not been reviewed by moderators
+	Offset            int        // Skip this many results; requires Type
+}
+
+func (opts SearchOptions) values() url.Values {
+	v := url.Values{}
+	opts.Pagination.setValues(v)
+	if opts.Type != "" {
+		v.Set("type", string(opts.Type))
+	}
+	if opts.Resolve {
+		v.Set("resolve", "true")
+	}
+	if opts.Following {
+		v.Set("following", "true")
+	}
+	if opts.AccountID != "" {
+		v.Set("account_id", opts.AccountID)
+	}
+	if opts.ExcludeUnreviewed {
+		v.Set("exclude_unreviewed", "true")
+	}
+	if opts.Offset > 0 {
+		v.Set("offset", strconv.Itoa(opts.Offset))
+	}
+	return v
+}
+
+// NotFoundError is returned if an URL could not be resolved.
+type NotFoundError struct {
+	URL string
+}
+
+func (err *NotFoundError) Error() string {
+	return "could not resolve " + err.URL
+}
+
 // Search returns results. If q is a URL, Mastodon will attempt to fetch the
 // provided account or status. Otherwise, it will do a local account and
 // hashtag search.
 func (search Search) Search(q string, resolve bool) (Results, error) {
+	return search.Query(q, &SearchOptions{Resolve: resolve})
+}
+
+// Query returns accounts, statuses and hashtags matching q. opts may be nil.
+func (search Search) Query(q string, opts *SearchOptions) (Results, error) {
 	r := Results{}
-	v := url.Values{
-		"q":       {q},
-		"resolve": {"false"},
+	v := url.Values{}
+	if opts != nil {
+		v = opts.values()
 	}
-	if resolve {
-		v.Set("resolve", "true")
+	v.Set("q", q)
+	return r, search.api.withPrefix("/api/v2/").Get("search", v, &r)
+}
+
+// ResolveStatus fetches a status by its URL, which may point to any
+// instance, and returns the local copy. A *NotFoundError is returned if the
+// URL could not be resolved to a status.
+func (search Search) ResolveStatus(u string) (Status, error) {
+	r, err := search.resolve(u, SearchStatuses)
+	if err != nil {
+		return Status{}, err
 	}
-	return r, search.api.Get("search", v, &r)
+	if len(r.Statuses) == 0 {
+		return Status{}, &NotFoundError{URL: u}
+	}
+	return r.Statuses[0], nil
+}
+
+// ResolveAccount fetches an account by its URL, which may point to any
+// instance, and returns the local copy. A *NotFoundError is returned if the
+// URL could not be resolved to an account.
+func (search Search) ResolveAccount(u string) (Account, error) {
+	r, err := search.resolve(u, SearchAccounts)
+	if err != nil {
+		return Account{}, err
+	}
+	if len(r.Accounts) == 0 {
+		return Account{}, &NotFoundError{URL: u}
+	}
+	return r.Accounts[0], nil
+}
+
+func (search Search) resolve(u string, typ SearchType) (Results, error) {
+	return search.Query(u, &SearchOptions{
+		Pagination: Pagination{Limit: 1},
+		Type:       typ,
+		Resolve:    true,
+	})
 }
@@ -1,8 +1,12 @@
 package mastodon
 
 import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"fmt"
-	"net/url"
+	"net/http"
+	"time"
 )
 
 // Statuses implements methods under /statuses.
@@ -10,6 +14,50 @@ type Statuses struct {
 	api *API
 }
 
+// Visibility is the visibility of a status.
+type Visibility string
+
+// Visibilities of statuses.
+const (
+	VisibilityPublic   Visibility = "public"   // Visible to everyone and shown in public timelines
+	VisibilityUnlisted Visibility = "unlisted" // Visible to everyone but not shown in public timelines
+	VisibilityPrivate  Visibility = "private"  // Visible to followers only
+	VisibilityDirect   Visibility = "direct"   // Visible to mentioned accounts only
+)
+
+// PostOptions holds the params to post a status. Empty values use the
+// server's defaults.
+type PostOptions struct {
+	InReplyToID    string       `json:"in_reply_to_id,omitempty"`   // Local ID of the status you want to reply to
+	MediaIDs       []string     `json:"media_ids,omitempty"`        // IDs of media to attach to the status
+	Sensitive      bool         `json:"sensitive,omitempty"`        // Mark the media of the status as NSFW
+	SpoilerText    string       `json:"spoiler_text,omitempty"`     // Text to be shown as a warning before the actual content
+	Visibility     Visibility   `json:"visibility,omitempty"`       // The visibility of the status
+	Language       string       `json:"language,omitempty"`         // ISO 639-1 code of the language of the status
+	Poll           *PollOptions `json:"poll,omitempty"`             // A poll to attach instead of media
+	ScheduledAt    *time.Time   `json:"scheduled_at,omitempty"`     // Publish the status at this time, at least 5 minutes in the future; see Schedule
+	QuotedStatusID string       `json:"quoted_status_id,omitempty"` // ID of a status to quote
+	IdempotencyKey string       `json:"-"`                          // Prevents posting duplicates when retrying; generated for each call if empty, see PostError
+}
+
+// NewIdempotencyKey returns a random key. Posting again using the same key
+// within an hour returns the status posted first instead of a duplicate.
+func NewIdempotencyKey() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("could not generate idempotency key: %v", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// PollOptions holds the params to attach a poll to a status.
+type PollOptions struct {
+	Options    []string `json:"options"`               // The possible answers
+	ExpiresIn  int      `json:"expires_in"`            // Duration of the poll in seconds
+	Multiple   bool     `json:"multiple,omitempty"`    // Allow multiple answers
+	HideTotals bool     `json:"hide_totals,omitempty"` // Hide the number of votes until the poll ends
+}
+
 // Get returns a status.
 func (statuses Statuses) Get(id string) (Status, error) {
 	s := Status{}
@@ -25,6 +73,9 @@ func (statuses Statuses) Context(id string) (Context, error) {
 }
 
 // Card returns a card.
+//
+// Deprecated: The endpoint has been removed in Mastodon 3.0. The card is
+// part of the status instead.
 func (statuses Statuses) Card(id string) (Card, error) {
 	c := Card{}
 	end := fmt.Sprintf("statuses/%s/card", id)
@@ -48,25 +99,72 @@ func (statuses Statuses) Favourites(id string) ([]Account, error) {
 	return a, statuses.api.Get(end, nil, &a)
 }
 
-// Update posts and returns a new status. Accepted params are:
-// in_reply_to_id: local ID of the status you want to reply to
-// media_ids: array of media IDs to attach to the status (maximum 4)
-// sensitive: set this to mark the media of the status as NSFW
-// spoiler_text: text to be shown as a warning before the actual content
-// visibility: either "direct", "private", "unlisted" or "public"
-func (statuses Statuses) Update(status string, v url.Values) (Status, error) {
+// Update posts and returns a new status. opts may be nil. Use Schedule to
+// post a status later. Unless opts holds an IdempotencyKey, a new key is
+// generated; a *PostError holds the key to retry with.
+func (statuses Statuses) Update(status string, opts *PostOptions) (Status, error) {
 	s := Status{}
-	if v == nil {
-		v = url.Values{}
+	if opts != nil && opts.ScheduledAt != nil {
+		return s, errors.New("could not post status: use Schedule to post scheduled statuses")
+	}
+	return s, statuses.post(status, opts, &s)
+}
+
+// Schedule schedules a status to be posted at opts.ScheduledAt, which must
+// be set. Idempotency keys are handled like in Update.
+func (statuses Statuses) Schedule(status string, opts *PostOptions) (ScheduledStatus, error) {
+	s := ScheduledStatus{}
+	if opts == nil || opts.ScheduledAt == nil {
+		return s, errors.New("could not schedule status: ScheduledAt is not set")
 	}
-	v.Set("status", status)
-	return s, statuses.api.Post("statuses", v, &s)
+	return s, statuses.post(status, opts, &s)
 }
 
-// Delete deletes a status.
-func (statuses Statuses) Delete(id string) error {
+// PostError is returned if posting a status fails. The status may have been
+// posted nonetheless, e.g. if the connection broke before the response was
+// received. Posting again using IdempotencyKey does not create a duplicate.
+type PostError struct {
+	IdempotencyKey string // The key the status has been posted with
+	Err            error  // The error posting the status
+}
+
+func (err *PostError) Error() string {
+	return err.Err.Error()
+}
+
+func (err *PostError) Unwrap() error {
+	return err.Err
+}
+
+// post posts a status and decodes the response into dest.
+func (statuses Statuses) post(status string, opts *PostOptions, dest interface{}) error {
+	key := ""
+	if opts != nil {
+		key = opts.IdempotencyKey
+	}
+	if key == "" {
+		k, err := NewIdempotencyKey()
+		if err != nil {
+			return err
+		}
+		key = k
+	}
+	body := struct {
+		Status string `json:"status"`
+		*PostOptions
+	}{status, opts}
+	header := http.Header{"Idempotency-Key": {key}}
+	if err := statuses.api.genericJSON(http.MethodPost, "statuses", body, header, dest); err != nil {
+		return &PostError{IdempotencyKey: key, Err: err}
+	}
+	return nil
+}
+
+// Delete deletes a status and returns it, including its source text.
+func (statuses Statuses) Delete(id string) (Status, error) {
+	s := Status{}
 	end := fmt.Sprintf("statuses/%s", id)
-	return statuses.api.Delete(end, nil, nil)
+	return s, statuses.api.Delete(end, nil, &s)
 }
 
 // Reblog rebloggs a status.
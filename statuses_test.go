@@ -0,0 +1,51 @@
+package mastodon
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestUpdateIdempotencyKey(t *testing.T) {
+	srv, app := newFakeServer(t,
+		response{http.StatusOK, `{"id": "1"}`},
+		response{http.StatusOK, `{"id": "2"}`},
+		response{http.StatusServiceUnavailable, `{}`},
+		response{http.StatusOK, `{"id": "3"}`},
+	)
+
+	// Options shared by different statuses are not changed and the statuses
+	// are posted using different keys.
+	opts := &PostOptions{Visibility: VisibilityUnlisted}
+	for _, status := range []string{"first", "second"} {
+		if _, err := app.Statuses.Update(status, opts); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if opts.IdempotencyKey != "" {
+		t.Errorf("got key %q in opts, want none", opts.IdempotencyKey)
+	}
+	first := srv.requests[0].Header.Get("Idempotency-Key")
+	second := srv.requests[1].Header.Get("Idempotency-Key")
+	if first == "" || first == second {
+		t.Errorf("got keys %q and %q, want different keys", first, second)
+	}
+
+	// A failed status is retried using the key of the error.
+	_, err := app.Statuses.Update("third", opts)
+	postErr := &PostError{}
+	if !errors.As(err, &postErr) {
+		t.Fatalf("got %v, want *PostError", err)
+	}
+	if key := srv.last(t).Header.Get("Idempotency-Key"); postErr.IdempotencyKey != key {
+		t.Fatalf("got key %q in error, want %q", postErr.IdempotencyKey, key)
+	}
+	retry := *opts
+	retry.IdempotencyKey = postErr.IdempotencyKey
+	if _, err := app.Statuses.Update("third", &retry); err != nil {
+		t.Fatal(err)
+	}
+	if got := srv.last(t).Header.Get("Idempotency-Key"); got != postErr.IdempotencyKey {
+		t.Errorf("retry: got key %q, want %q", got, postErr.IdempotencyKey)
+	}
+}
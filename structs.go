@@ -1,20 +1,37 @@
 package mastodon
 
+import "encoding/json"
+
 // Account holds informations about an account.
 type Account struct {
-	ID          string `json:"id"`              // The ID of the account
-	Username    string `json:"username"`        // The username of the account
-	Acct        string `json:"acct"`            // Equals username for local users, includes @domain for remote ones
-	DisplayName string `json:"display_name"`    // The account's display name
-	Note        string `json:"note"`            // Biography of user
-	URL         string `json:"url"`             // URL of the user's profile page (can be remote)
-	Avatar      string `json:"avatar"`          // URL to the avatar image
-	Header      string `json:"header"`          // URL to the header image
-	Locked      bool   `json:"locked"`          // Boolean for when the account cannot be followed without waiting for approval first
-	CreatedAt   string `json:"created_at"`      // The time the account was created
-	Followers   int    `json:"followers_count"` // The number of followers for the account
-	Following   int    `json:"following_count"` // The number of accounts the given account is following
-	Statuses    int    `json:"statuses_count"`  // The number of statuses the account has made
+	ID           string  `json:"id"`              // The ID of the account
+	Username     string  `json:"username"`        // The username of the account
+	Acct         string  `json:"acct"`            // Equals username for local users, includes @domain for remote ones
+	DisplayName  string  `json:"display_name"`    // The account's display name
+	Note         string  `json:"note"`            // Biography of user
+	URL          string  `json:"url"`             // URL of the user's profile page (can be remote)
+	Avatar       string  `json:"avatar"`          // URL to the avatar image
+	Header       string  `json:"header"`          // URL to the header image
+	Locked       bool    `json:"locked"`          // Boolean for when the account cannot be followed without waiting for approval first
+	Bot          bool    `json:"bot"`             // Whether the account performs automated actions
+	Discoverable bool    `json:"discoverable"`    // Whether the account has opted into discovery features such as the profile directory
+	Indexable    bool    `json:"indexable"`       // Whether the account's public posts may be searched by anyone
+	Fields       []Field `json:"fields"`          // Additional metadata attached to the profile as name-value pairs
+	CreatedAt    string  `json:"created_at"`      // The time the account was created
+	Followers    int     `json:"followers_count"` // The number of followers for the account
+	Following    int     `json:"following_count"` // The number of accounts the given account is following
+	Statuses     int     `json:"statuses_count"`  // The number of statuses the account has made
+}
+
+// AccountWarning holds informations about a moderation action taken against
+// an account.
+type AccountWarning struct {
+	ID            string   `json:"id"`             // The ID of the warning
+	Action        string   `json:"action"`         // One of: none, disable, mark_statuses_as_sensitive, delete_statuses, sensitive, silence, suspend
+	Text          string   `json:"text"`           // The message of the moderator
+	StatusIDs     []string `json:"status_ids"`     // The statuses affected by the action
+	TargetAccount *Account `json:"target_account"` // The account the action was taken against
+	CreatedAt     string   `json:"created_at"`     // The time the action was taken
 }
 
 // Application holds informations about an application.
@@ -50,9 +67,63 @@ type Context struct {
 	Descendants []Status `json:"descendants"` // The descendants of the status in the conversation, as a list of Statuses
 }
 
+// CredentialAccount holds informations about the authenticated user's
+// account, including its source.
+type CredentialAccount struct {
+	Account
+	Source Source `json:"source"` // Profile and posting defaults as entered by the user
+}
+
+// DomainBlock holds informations about a domain blocked by an instance.
+type DomainBlock struct {
+	Domain   string `json:"domain"`   // The blocked domain, possibly partially obfuscated
+	Digest   string `json:"digest"`   // The SHA256 hash of the domain
+	Severity string `json:"severity"` // One of: silence, suspend
+	Comment  string `json:"comment"`  // The reason for the block, if given
+}
+
 // Error holds informations about an error.
 type Error struct {
-	Error string `json:"error"` // A textual description of the error
+	Error   string                   `json:"error"`   // A textual description of the error
+	Details map[string][]ErrorDetail `json:"details"` // Validation errors by field name, e.g. "email" or "username"
+}
+
+// ErrorDetail holds informations about a validation error of a single field.
+type ErrorDetail struct {
+	Error       string `json:"error"`       // An error code, e.g. "ERR_TAKEN" or "ERR_BLOCKED"
+	Description string `json:"description"` // A textual description of the error
+}
+
+// ExtendedDescription holds informations about the extended description of
+// an instance.
+type ExtendedDescription struct {
+	UpdatedAt string `json:"updated_at"` // The time the description was last updated
+	Content   string `json:"content"`    // The description as HTML
+}
+
+// FeaturedTag holds informations about a hashtag featured on a profile.
+type FeaturedTag struct {
+	ID            string      `json:"id"`             // The ID of the featured tag
+	Name          string      `json:"name"`           // The hashtag, not including the preceding #
+	URL           string      `json:"url"`            // URL of the statuses of the account using the hashtag
+	StatusesCount json.Number `json:"statuses_count"` // The number of statuses of the account using the hashtag
+	LastStatusAt  string      `json:"last_status_at"` // The date of the last status of the account using the hashtag
+}
+
+// Field holds informations about a profile metadata field.
+type Field struct {
+	Name       string `json:"name"`        // The key of the field
+	Value      string `json:"value"`       // The value of the field; HTML in Accounts, plain text in Sources
+	VerifiedAt string `json:"verified_at"` // null or the time the link in the value was verified
+}
+
+// GroupedNotifications holds informations about notification groups and the
+// accounts and statuses they refer to.
+type GroupedNotifications struct {
+	Accounts           []Account           `json:"accounts"`            // The accounts referred to by the groups
+	PartialAccounts    []PartialAccount    `json:"partial_accounts"`    // Accounts with only a subset of fields, if requested
+	Statuses           []Status            `json:"statuses"`            // The statuses referred to by the groups
+	NotificationGroups []NotificationGroup `json:"notification_groups"` // The notification groups
 }
 
 // Instance holds informations about an instance.
@@ -63,6 +134,100 @@ type Instance struct {
 	Email       string `json:"email"`       // An email address which can be used to contact the instance administrator
 }
 
+// InstanceActivity holds weekly statistics about an instance.
+type InstanceActivity struct {
+	Week          string `json:"week"`          // UNIX timestamp of midnight of the first day of the week
+	Statuses      string `json:"statuses"`      // The number of statuses created that week
+	Logins        string `json:"logins"`        // The number of user logins that week
+	Registrations string `json:"registrations"` // The number of user registrations that week
+}
+
+// InstanceConfiguration holds informations about the limits and features of
+// an instance.
+type InstanceConfiguration struct {
+	URLs struct {
+		Streaming string `json:"streaming"` // The Websockets URL for the streaming API
+	} `json:"urls"`
+	Vapid struct {
+		PublicKey string `json:"public_key"` // The key to verify Web Push notifications
+	} `json:"vapid"`
+	Accounts struct {
+		MaxFeaturedTags   int `json:"max_featured_tags"`   // The maximum number of featured tags per account
+		MaxPinnedStatuses int `json:"max_pinned_statuses"` // The maximum number of pinned statuses per account
+	} `json:"accounts"`
+	Statuses struct {
+		MaxCharacters            int `json:"max_characters"`              // The maximum number of characters per status
+		MaxMediaAttachments      int `json:"max_media_attachments"`       // The maximum number of attachments per status
+		CharactersReservedPerURL int `json:"characters_reserved_per_url"` // The number of characters each URL counts as
+	} `json:"statuses"`
+	MediaAttachments struct {
+		SupportedMIMETypes  []string `json:"supported_mime_types"`   // The MIME types of supported attachments
+		ImageSizeLimit      int      `json:"image_size_limit"`       // The maximum size of images in bytes
+		ImageMatrixLimit    int      `json:"image_matrix_limit"`     // The maximum number of pixels of images
+		VideoSizeLimit      int      `json:"video_size_limit"`       // The maximum size of videos in bytes
+		VideoFrameRateLimit int      `json:"video_frame_rate_limit"` // The maximum frame rate of videos
+		VideoMatrixLimit    int      `json:"video_matrix_limit"`     // The maximum number of pixels per frame of videos
+	} `json:"media_attachments"`
+	Polls struct {
+		MaxOptions             int `json:"max_options"`               // The maximum number of options per poll
+		MaxCharactersPerOption int `json:"max_characters_per_option"` // The maximum number of characters per option
+		MinExpiration          int `json:"min_expiration"`            // The shortest duration of a poll in seconds
+		MaxExpiration          int `json:"max_expiration"`            // The longest duration of a poll in seconds
+	} `json:"polls"`
+	Translation struct {
+		Enabled bool `json:"enabled"` // Whether statuses can be translated
+	} `json:"translation"`
+}
+
+// InstanceV2 holds informations about an instance, including its
+// configuration.
+type InstanceV2 struct {
+	Domain      string `json:"domain"`      // The domain of the instance
+	Title       string `json:"title"`       // The instance's title
+	Version     string `json:"version"`     // The version of Mastodon the instance is running
+	SourceURL   string `json:"source_url"`  // URL of the source code of the instance
+	Description string `json:"description"` // A short description of the instance
+	Usage       struct {
+		Users struct {
+			ActiveMonth int `json:"active_month"` // The number of users active in the past 4 weeks
+		} `json:"users"`
+	} `json:"usage"`
+	Thumbnail struct {
+		URL      string `json:"url"`      // URL of the thumbnail image
+		Blurhash string `json:"blurhash"` // A hash to display a placeholder while the image loads
+		Versions struct {
+			X1 string `json:"@1x"` // URL of the thumbnail for normal-density displays
+			X2 string `json:"@2x"` // URL of the thumbnail for high-density displays
+		} `json:"versions"`
+	} `json:"thumbnail"`
+	Languages     []string              `json:"languages"`     // ISO 639-1 codes of the primary languages of the instance
+	Configuration InstanceConfiguration `json:"configuration"` // The limits and features of the instance
+	Registrations struct {
+		Enabled          bool   `json:"enabled"`           // Whether registrations are open
+		ApprovalRequired bool   `json:"approval_required"` // Whether registrations require approval by moderators
+		Message          string `json:"message"`           // A message shown if registrations are closed
+		URL              string `json:"url"`               // URL of an external sign-up form, if any
+	} `json:"registrations"`
+	Contact struct {
+		Email   string  `json:"email"`   // An email address to contact the admins
+		Account Account `json:"account"` // An account to contact the admins
+	} `json:"contact"`
+	Rules []Rule `json:"rules"` // The rules of the instance
+}
+
+// Language holds informations about a language.
+type Language struct {
+	Code string `json:"code"` // The ISO 639-1 code of the language
+	Name string `json:"name"` // The name of the language in English
+}
+
+// Marker holds informations about the read position in a timeline.
+type Marker struct {
+	LastReadID string `json:"last_read_id"` // The ID of the most recently read status or notification
+	Version    int    `json:"version"`      // Incremented on every update
+	UpdatedAt  string `json:"updated_at"`   // The time the marker was last updated
+}
+
 // Mention holds informations about a mention.
 type Mention struct {
 	ID       string `json:"id"`       // Account ID
@@ -73,33 +238,185 @@ type Mention struct {
 
 // Notification holds informations about a notification.
 type Notification struct {
-	ID        string   `json:"id"`         // The notification ID
-	Type      string   `json:"type"`       // One of: "mention", "reblog", "favourite", "follow"
-	CreatedAt string   `json:"created_at"` // The time the notification was created
-	Account   *Account `json:"account"`    // The Account sending the notification to the user
-	Status    *Status  `json:"status"`     // The Status associated with the notification, if applicable
+	ID                string                      `json:"id"`                           // The notification ID
+	Type              NotificationType            `json:"type"`                         // The type of the notification, e.g. NotificationMention
+	GroupKey          string                      `json:"group_key"`                    // The key of the group the notification belongs to
+	CreatedAt         string                      `json:"created_at"`                   // The time the notification was created
+	Account           *Account                    `json:"account"`                      // The Account sending the notification to the user
+	Status            *Status                     `json:"status"`                       // The Status associated with the notification, if applicable
+	Report            *Report                     `json:"report"`                       // The Report associated with NotificationAdminReport
+	Event             *RelationshipSeveranceEvent `json:"relationship_severance_event"` // The event associated with NotificationSeveredRelationship
+	ModerationWarning *AccountWarning             `json:"moderation_warning"`           // The warning associated with NotificationModerationWarning
+}
+
+// NotificationGroup holds informations about a group of notifications.
+type NotificationGroup struct {
+	GroupKey                 string                      `json:"group_key"`                    // Identifies the group
+	NotificationsCount       int                         `json:"notifications_count"`          // The number of notifications in the group
+	Type                     NotificationType            `json:"type"`                         // The type of the notifications
+	MostRecentNotificationID string                      `json:"most_recent_notification_id"`  // The ID of the most recent notification in the group
+	PageMinID                string                      `json:"page_min_id"`                  // The ID of the oldest notification of the group in the page
+	PageMaxID                string                      `json:"page_max_id"`                  // The ID of the newest notification of the group in the page
+	LatestPageNotificationAt string                      `json:"latest_page_notification_at"`  // The time of the newest notification of the group in the page
+	SampleAccountIDs         []string                    `json:"sample_account_ids"`           // IDs of some of the accounts that caused the notifications
+	StatusID                 string                      `json:"status_id"`                    // The ID of the status associated with the group, if applicable
+	Report                   *Report                     `json:"report"`                       // The Report associated with NotificationAdminReport
+	Event                    *RelationshipSeveranceEvent `json:"relationship_severance_event"` // The event associated with NotificationSeveredRelationship
+	ModerationWarning        *AccountWarning             `json:"moderation_warning"`           // The warning associated with NotificationModerationWarning
+}
+
+// NotificationPolicy holds informations about the way notifications are
+// filtered.
+type NotificationPolicy struct {
+	ForNotFollowing    PolicyAction `json:"for_not_following"`    // Notifications from accounts the user is not following
+	ForNotFollowers    PolicyAction `json:"for_not_followers"`    // Notifications from accounts not following the user
+	ForNewAccounts     PolicyAction `json:"for_new_accounts"`     // Notifications from accounts created in the past 30 days
+	ForPrivateMentions PolicyAction `json:"for_private_mentions"` // Unsolicited private mentions
+	ForLimitedAccounts PolicyAction `json:"for_limited_accounts"` // Notifications from accounts limited by moderators
+	Summary            struct {
+		PendingRequestsCount      int `json:"pending_requests_count"`      // The number of pending notification requests
+		PendingNotificationsCount int `json:"pending_notifications_count"` // The number of filtered notifications
+	} `json:"summary"`
+}
+
+// NotificationRequest holds informations about filtered notifications from
+// a single account.
+type NotificationRequest struct {
+	ID                 string  `json:"id"`                  // The ID of the request
+	CreatedAt          string  `json:"created_at"`          // The time the request was created
+	UpdatedAt          string  `json:"updated_at"`          // The time the request was last updated
+	Account            Account `json:"account"`             // The account that caused the notifications
+	NotificationsCount string  `json:"notifications_count"` // The number of filtered notifications
+	LastStatus         *Status `json:"last_status"`         // The most recent status of the account, if any
+}
+
+// PartialAccount holds the informations about an account needed to display
+// an avatar.
+type PartialAccount struct {
+	ID     string `json:"id"`     // The ID of the account
+	Acct   string `json:"acct"`   // Equals username for local users, includes @domain for remote ones
+	URL    string `json:"url"`    // URL of the user's profile page (can be remote)
+	Avatar string `json:"avatar"` // URL to the avatar image
+	Locked bool   `json:"locked"` // Whether the account cannot be followed without waiting for approval first
+	Bot    bool   `json:"bot"`    // Whether the account performs automated actions
+}
+
+// PrivacyPolicy holds informations about the privacy policy of an instance.
+type PrivacyPolicy struct {
+	UpdatedAt string `json:"updated_at"` // The time the policy was last updated
+	Content   string `json:"content"`    // The policy as HTML
+}
+
+// PushNotification holds informations about a notification received via Web
+// Push.
+type PushNotification struct {
+	AccessToken      string           `json:"access_token"`      // The token of the subscription
+	PreferredLocale  string           `json:"preferred_locale"`  // The language of the user
+	NotificationID   json.Number      `json:"notification_id"`   // The ID of the notification, see Notifications.GetSingle
+	NotificationType NotificationType `json:"notification_type"` // The type of the notification
+	Icon             string           `json:"icon"`              // URL to the avatar of the account causing the notification
+	Title            string           `json:"title"`             // The title of the notification
+	Body             string           `json:"body"`              // The text of the notification
+}
+
+// PushSubscription holds informations about a Web Push subscription.
+type PushSubscription struct {
+	ID        json.Number `json:"id"`         // The ID of the subscription
+	Endpoint  string      `json:"endpoint"`   // The URL notifications are pushed to
+	Standard  bool        `json:"standard"`   // Whether notifications are encrypted using RFC 8291
+	Alerts    PushAlerts  `json:"alerts"`     // The types of notifications pushed
+	Policy    PushPolicy  `json:"policy"`     // The accounts whose notifications are pushed
+	ServerKey string      `json:"server_key"` // The server's public key to verify pushes
 }
 
 // Relationship holds informations about a relationship.
 type Relationship struct {
-	Following  bool `json:"following"`   // Whether the user is currently following the account
-	FollowedBy bool `json:"followed_by"` // Whether the user is currently being followed by the account
-	Blocking   bool `json:"blocking"`    // Whether the user is currently blocking the account
-	Muting     bool `json:"muting"`      // Whether the user is currently muting the account
-	Requested  bool `json:"requested"`   // Whether the user has requested to follow the account
+	ID             string   `json:"id"`              // The account ID
+	Following      bool     `json:"following"`       // Whether the user is currently following the account
+	ShowingReblogs bool     `json:"showing_reblogs"` // Whether the user is receiving the account's reblogs in the home timeline
+	Notifying      bool     `json:"notifying"`       // Whether the user is notified when the account posts
+	Languages      []string `json:"languages"`       // The languages of the account's statuses the user is following, all if empty
+	FollowedBy     bool     `json:"followed_by"`     // Whether the user is currently being followed by the account
+	Blocking       bool     `json:"blocking"`        // Whether the user is currently blocking the account
+	Muting         bool     `json:"muting"`          // Whether the user is currently muting the account
+	Requested      bool     `json:"requested"`       // Whether the user has requested to follow the account
+	Endorsed       bool     `json:"endorsed"`        // Whether the user is featuring the account on their profile
+	Note           string   `json:"note"`            // The user's private note on the account
+}
+
+// RelationshipSeveranceEvent holds informations about relationships that
+// have been severed by a moderation action.
+type RelationshipSeveranceEvent struct {
+	ID             string `json:"id"`              // The ID of the event
+	Type           string `json:"type"`            // One of: domain_block, user_domain_block, account_suspension
+	Purged         bool   `json:"purged"`          // Whether the list of severed relationships is unavailable
+	TargetName     string `json:"target_name"`     // The name of the blocked domain or suspended account
+	FollowersCount int    `json:"followers_count"` // The number of followers that were removed
+	FollowingCount int    `json:"following_count"` // The number of followed accounts that were removed
+	CreatedAt      string `json:"created_at"`      // The time the event happened
 }
 
 // Report holds informations about a report.
 type Report struct {
-	ID          string `json:"id"`           // The ID of the report
-	ActionTaken string `json:"action_taken"` // The action taken in response to the report
+	ID            string   `json:"id"`              // The ID of the report
+	ActionTaken   bool     `json:"action_taken"`    // Whether an action was taken in response to the report
+	ActionTakenAt string   `json:"action_taken_at"` // null or the time an action was taken
+	Category      string   `json:"category"`        // One of: spam, legal, violation, other
+	Comment       string   `json:"comment"`         // The reason given for the report
+	Forwarded     bool     `json:"forwarded"`       // Whether the report was forwarded to a remote instance
+	CreatedAt     string   `json:"created_at"`      // The time the report was filed
+	StatusIDs     []string `json:"status_ids"`      // The reported statuses
+	RuleIDs       []string `json:"rule_ids"`        // The violated rules
+	TargetAccount *Account `json:"target_account"`  // The reported account
 }
 
 // Results holds informations about results.
 type Results struct {
 	Accounts []Account `json:"accounts"` // An array of matched Accounts
 	Statuses []Status  `json:"statuses"` // An array of matchhed Statuses
-	Hashtags []string  `json:"hashtags"` // An array of matched hashtags, as strings
+	Hashtags []Tag     `json:"hashtags"` // An array of matched Tags
+}
+
+// Rule holds informations about a rule of an instance.
+type Rule struct {
+	ID   string `json:"id"`   // The ID of the rule
+	Text string `json:"text"` // The rule
+	Hint string `json:"hint"` // An explanation of the rule
+}
+
+// ScheduledStatus holds informations about a status scheduled to be posted
+// later.
+type ScheduledStatus struct {
+	ID               string                `json:"id"`                // The ID of the scheduled status
+	ScheduledAt      string                `json:"scheduled_at"`      // The time the status will be posted
+	Params           ScheduledStatusParams `json:"params"`            // The params the status will be posted with
+	MediaAttachments []Attachment          `json:"media_attachments"` // The media attached to the status
+}
+
+// ScheduledStatusParams holds informations about the params of a scheduled
+// status.
+type ScheduledStatusParams struct {
+	Text          string       `json:"text"`           // The text of the status
+	Poll          *PollOptions `json:"poll"`           // null or the poll attached to the status
+	MediaIDs      []string     `json:"media_ids"`      // IDs of the media attached to the status
+	Sensitive     bool         `json:"sensitive"`      // Whether the media of the status is marked as NSFW
+	SpoilerText   string       `json:"spoiler_text"`   // Text to be shown as a warning before the actual content
+	Visibility    Visibility   `json:"visibility"`     // The visibility of the status
+	InReplyToID   string       `json:"in_reply_to_id"` // null or the ID of the status it replies to
+	Language      string       `json:"language"`       // The language of the status
+	ApplicationID json.Number  `json:"application_id"` // The ID of the application that scheduled the status
+	Idempotency   string       `json:"idempotency"`    // The idempotency key the status was scheduled with
+}
+
+// Source holds informations about the source of the authenticated user's
+// profile.
+type Source struct {
+	Note                string  `json:"note"`                  // Biography of user as plain text
+	Fields              []Field `json:"fields"`                // Profile metadata fields as plain text
+	Privacy             string  `json:"privacy"`               // The default visibility of new statuses
+	Sensitive           bool    `json:"sensitive"`             // Whether new statuses are marked sensitive by default
+	Language            string  `json:"language"`              // The default language of new statuses
+	FollowRequestsCount int     `json:"follow_requests_count"` // The number of pending follow requests
 }
 
 // Status holds informations about a status.
@@ -119,15 +436,57 @@ type Status struct {
 	Favourited         bool         `json:"favourited"`             // Whether the authenticated user has favourited the status
 	Sensitive          bool         `json:"sensitive"`              // Whether media attachments should be hidden by default
 	SpoilerText        string       `json:"spoiler_text"`           // If not empty, warning text that should be displayed before the actual content
-	Visibility         string       `json:"visibility"`             // One of: public, unlisted, private, direct
+	Visibility         Visibility   `json:"visibility"`             // One of: public, unlisted, private, direct
 	MediaAttachments   []Attachment `json:"media_attachments"`      // An array of Attachments
 	Mentions           []Mention    `json:"mentions"`               // An array of Mentions
 	Tags               []Tag        `json:"tags"`                   // An array of Tags
 	Application        *Application `json:"application"`            // Application from which the status was posted
+	Card               *Card        `json:"card"`                   // null or the preview card of the first link in the status
+	Text               string       `json:"text"`                   // The plain text source of the status, only returned when deleting it
+}
+
+// Suggestion holds informations about an account suggested to follow.
+type Suggestion struct {
+	Sources []string `json:"sources"` // Why the account is suggested, e.g. "featured" or "most_followed"
+	Account Account  `json:"account"` // The suggested account
 }
 
 // Tag holds informations about a tag.
 type Tag struct {
-	Name string `json:"name"` // The hashtag, not including the preceding #
-	URL  string `json:"url"`  // The URL of the hashtag
+	ID        string       `json:"id"`        // The ID of the hashtag
+	Name      string       `json:"name"`      // The hashtag, not including the preceding #
+	URL       string       `json:"url"`       // The URL of the hashtag
+	History   []TagHistory `json:"history"`   // Usage statistics of the recent days
+	Following bool         `json:"following"` // Whether the authenticated user is following the hashtag
+}
+
+// TagHistory holds informations about the usage of a tag on a single day.
+type TagHistory struct {
+	Day      string `json:"day"`      // UNIX timestamp of midnight of the day
+	Uses     string `json:"uses"`     // The number of statuses using the tag that day
+	Accounts string `json:"accounts"` // The number of accounts using the tag that day
+}
+
+// Token holds informations about an OAuth token.
+type Token struct {
+	AccessToken string `json:"access_token"` // The token used to authenticate requests
+	TokenType   string `json:"token_type"`   // Usually "Bearer"
+	Scope       string `json:"scope"`        // The scopes granted to the token, separated by spaces
+	CreatedAt   int64  `json:"created_at"`   // When the token was generated, as a UNIX timestamp
+}
+
+// TrendsLink holds informations about a trending link.
+type TrendsLink struct {
+	Card
+	Type         string       `json:"type"`          // One of: link, photo, video, rich
+	AuthorName   string       `json:"author_name"`   // The author of the linked resource
+	AuthorURL    string       `json:"author_url"`    // A link to the author of the linked resource
+	ProviderName string       `json:"provider_name"` // The provider of the linked resource
+	ProviderURL  string       `json:"provider_url"`  // A link to the provider of the linked resource
+	HTML         string       `json:"html"`          // HTML to embed the linked resource
+	Width        int          `json:"width"`         // The width of the preview in pixels
+	Height       int          `json:"height"`        // The height of the preview in pixels
+	EmbedURL     string       `json:"embed_url"`     // A link to the embeddable resource
+	Blurhash     string       `json:"blurhash"`      // A hash to display a placeholder while the image loads
+	History      []TagHistory `json:"history"`       // Usage statistics of the recent days
 }
@@ -0,0 +1,29 @@
+package mastodon
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Suggestions implements methods under /suggestions.
+type Suggestions struct {
+	api *API
+}
+
+// Get returns accounts the authenticated user might want to follow. A zero
+// limit uses the server's default.
+func (suggestions Suggestions) Get(limit int) ([]Suggestion, error) {
+	s := []Suggestion{}
+	v := url.Values{}
+	if limit > 0 {
+		v.Set("limit", strconv.Itoa(limit))
+	}
+	return s, suggestions.api.withPrefix("/api/v2/").Get("suggestions", v, &s)
+}
+
+// Remove stops suggesting an account.
+func (suggestions Suggestions) Remove(accountID string) error {
+	end := fmt.Sprintf("suggestions/%s", accountID)
+	return suggestions.api.Delete(end, nil, nil)
+}
@@ -0,0 +1,42 @@
+package mastodon
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// Tags implements methods under /tags.
+type Tags struct {
+	api *API
+}
+
+// Get returns a hashtag, not including the preceding #.
+func (tags Tags) Get(name string) (Tag, error) {
+	t := Tag{}
+	end := fmt.Sprintf("tags/%s", url.PathEscape(name))
+	return t, tags.api.Get(end, nil, &t)
+}
+
+// Follow follows a hashtag. Statuses using it are shown in the home
+// timeline.
+func (tags Tags) Follow(name string) (Tag, error) {
+	t := Tag{}
+	end := fmt.Sprintf("tags/%s/follow", url.PathEscape(name))
+	return t, tags.api.Post(end, nil, &t)
+}
+
+// Unfollow unfollows a hashtag.
+func (tags Tags) Unfollow(name string) (Tag, error) {
+	t := Tag{}
+	end := fmt.Sprintf("tags/%s/unfollow", url.PathEscape(name))
+	return t, tags.api.Post(end, nil, &t)
+}
+
+// Followed returns the hashtags followed by the authenticated user and the
+// params of the pages next to them. p may be nil; use the returned Page to
+// request further pages.
+func (tags Tags) Followed(p *Pagination) ([]Tag, Page, error) {
+	t := []Tag{}
+	page, err := tags.api.getPage("followed_tags", paginationValues(p), &t)
+	return t, page, err
+}
@@ -0,0 +1,151 @@
+package mastodon
+
+import (
+	"sort"
+	"time"
+)
+
+// Thread is a conversation as a tree of statuses.
+type Thread struct {
+	Root     *ThreadNode   // The root of the conversation containing Focus
+	Focus    *ThreadNode   // The status the context has been fetched for
+	Detached []*ThreadNode // Roots of further statuses not connected to Root
+}
+
+// ThreadNode is a status in a thread.
+type ThreadNode struct {
+	ID       string        // The ID of the status
+	Status   *Status       // The status; nil if it is missing, e.g. not yet fetched from another instance
+	Parent   *ThreadNode   // The status replied to; nil for roots
+	Children []*ThreadNode // The replies to the status, oldest first
+	Depth    int           // The number of ancestors; 0 for roots
+}
+
+// NewThread builds a thread from a status and its context as returned by
+// Statuses.Context. Statuses replying to statuses missing from the context
+// are attached to placeholder nodes without a Status. Replies forming a cycle
+// are not linked.
+func NewThread(status Status, ctx Context) *Thread {
+	nodes := map[string]*ThreadNode{}
+	statuses := append(append(append([]Status{}, ctx.Ancestors...), status), ctx.Descendants...)
+	for i := range statuses {
+		nodes[statuses[i].ID] = &ThreadNode{ID: statuses[i].ID, Status: &statuses[i]}
+	}
+
+	roots := []*ThreadNode{}
+	for _, s := range statuses {
+		node := nodes[s.ID]
+		if s.InReplyToID == "" {
+			roots = append(roots, node)
+			continue
+		}
+		parent, ok := nodes[s.InReplyToID]
+		if !ok {
+			parent = &ThreadNode{ID: s.InReplyToID}
+			nodes[parent.ID] = parent
+			roots = append(roots, parent)
+		}
+		if parent.descendantOf(node) {
+			// The reply closing a cycle becomes a root instead.
+			roots = append(roots, node)
+			continue
+		}
+		node.Parent = parent
+		parent.Children = append(parent.Children, node)
+	}
+
+	t := &Thread{Focus: nodes[status.ID]}
+	t.Root = t.Focus
+	for t.Root.Parent != nil {
+		t.Root = t.Root.Parent
+	}
+	for _, root := range roots {
+		root.sort(0)
+		if root != t.Root {
+			t.Detached = append(t.Detached, root)
+		}
+	}
+	return t
+}
+
+// descendantOf reports whether the node is other or one of its replies.
+func (node *ThreadNode) descendantOf(other *ThreadNode) bool {
+	for ; node != nil; node = node.Parent {
+		if node == other {
+			return true
+		}
+	}
+	return false
+}
+
+// sort orders the children of the node by their creation and sets the
+// depth of the node and its children.
+func (node *ThreadNode) sort(depth int) {
+	node.Depth = depth
+	sort.SliceStable(node.Children, func(i, j int) bool {
+		return node.Children[i].before(node.Children[j])
+	})
+	for _, child := range node.Children {
+		child.sort(depth + 1)
+	}
+}
+
+// before reports whether the node has been created before other. Nodes
+// without a valid creation time are compared by their ID.
+func (node *ThreadNode) before(other *ThreadNode) bool {
+	if node.Status != nil && other.Status != nil {
+		a, errA := time.Parse(time.RFC3339, node.Status.CreatedAt)
+		b, errB := time.Parse(time.RFC3339, other.Status.CreatedAt)
+		if errA == nil && errB == nil && !a.Equal(b) {
+			return a.Before(b)
+		}
+	}
+	return compareIDs(node.ID, other.ID) < 0
+}
+
+// Flatten returns the node and all of its replies in reading order, i.e.
+// depth first with older replies first.
+func (node *ThreadNode) Flatten() []*ThreadNode {
+	nodes := []*ThreadNode{node}
+	for _, child := range node.Children {
+		nodes = append(nodes, child.Flatten()...)
+	}
+	return nodes
+}
+
+// SelfThread returns the chain of statuses the author of the focused status
+// posted as replies to themselves, containing the focused status. It starts
+// at the author's first status of the chain and follows the author's
+// earliest reply to each status.
+func (t *Thread) SelfThread() []Status {
+	author := func(node *ThreadNode) string {
+		if node == nil || node.Status == nil || node.Status.Account == nil {
+			return ""
+		}
+		return node.Status.Account.ID
+	}
+	id := author(t.Focus)
+	if id == "" {
+		return []Status{}
+	}
+
+	node := t.Focus
+	for author(node.Parent) == id {
+		node = node.Parent
+	}
+	chain := []Status{*node.Status}
+	for {
+		var next *ThreadNode
+		for _, child := range node.Children {
+			if author(child) == id {
+				next = child
+				break
+			}
+		}
+		if next == nil {
+			return chain
+		}
+		chain = append(chain, *next.Status)
+		node = next
+	}
+}
@@ -0,0 +1,127 @@
+package mastodon
+
+import (
+	"reflect"
+	"testing"
+)
+
+// testStatus returns a status by author replying to inReplyToID.
+func testStatus(id, inReplyToID, author, createdAt string) Status {
+	return Status{ID: id, InReplyToID: inReplyToID, Account: &Account{ID: author}, CreatedAt: createdAt}
+}
+
+// nodeIDs returns the IDs of nodes.
+func nodeIDs(nodes []*ThreadNode) []string {
+	ids := []string{}
+	for _, node := range nodes {
+		ids = append(ids, node.ID)
+	}
+	return ids
+}
+
+// statusIDs returns the IDs of statuses.
+func statusIDs(statuses []Status) []string {
+	ids := []string{}
+	for _, s := range statuses {
+		ids = append(ids, s.ID)
+	}
+	return ids
+}
+
+func TestNewThread(t *testing.T) {
+	focus := testStatus("2", "1", "a", "2024-01-01T00:02:00Z")
+	thread := NewThread(focus, Context{
+		Ancestors: []Status{testStatus("1", "", "a", "2024-01-01T00:01:00Z")},
+		Descendants: []Status{
+			// Later replies with lower IDs are sorted by creation.
+			testStatus("30", "2", "b", "2024-01-01T00:05:00Z"),
+			testStatus("40", "2", "c", "2024-01-01T00:04:00Z"),
+			// Replies without a valid creation time are sorted by ID.
+			testStatus("100", "2", "d", ""),
+			testStatus("99", "2", "d", ""),
+			testStatus("5", "30", "a", "2024-01-01T00:06:00Z"),
+			// Replies to a status missing from the context.
+			testStatus("7", "6", "e", "2024-01-01T00:07:00Z"),
+			testStatus("8", "7", "e", "2024-01-01T00:08:00Z"),
+		},
+	})
+
+	if thread.Root.ID != "1" || thread.Focus.ID != "2" || thread.Focus.Parent != thread.Root {
+		t.Fatalf("got root %s and focus %s, want 1 and 2", thread.Root.ID, thread.Focus.ID)
+	}
+	if got, want := nodeIDs(thread.Root.Flatten()), []string{"1", "2", "40", "30", "5", "99", "100"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("flattened: got %v, want %v", got, want)
+	}
+	if got := thread.Root.Children[0].Children[1].Children[0]; got.ID != "5" || got.Depth != 3 {
+		t.Errorf("got %s at depth %d, want 5 at depth 3", got.ID, got.Depth)
+	}
+
+	if len(thread.Detached) != 1 {
+		t.Fatalf("got %d detached roots, want 1", len(thread.Detached))
+	}
+	placeholder := thread.Detached[0]
+	if placeholder.ID != "6" || placeholder.Status != nil || placeholder.Depth != 0 {
+		t.Errorf("got detached root %s with status %v, want placeholder 6", placeholder.ID, placeholder.Status)
+	}
+	if got, want := nodeIDs(placeholder.Flatten()), []string{"6", "7", "8"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("detached: got %v, want %v", got, want)
+	}
+}
+
+func TestNewThreadMissingParent(t *testing.T) {
+	// The focused status replies to a status that could not be fetched.
+	thread := NewThread(testStatus("2", "1", "a", ""), Context{})
+	if thread.Root.ID != "1" || thread.Root.Status != nil {
+		t.Errorf("got root %s with status %v, want placeholder 1", thread.Root.ID, thread.Root.Status)
+	}
+	if thread.Focus.Depth != 1 || len(thread.Detached) != 0 {
+		t.Errorf("got focus at depth %d and %d detached roots, want 1 and 0", thread.Focus.Depth, len(thread.Detached))
+	}
+}
+
+func TestSelfThread(t *testing.T) {
+	ctx := Context{
+		Ancestors: []Status{
+			testStatus("1", "", "b", "2024-01-01T00:01:00Z"),
+			testStatus("2", "1", "a", "2024-01-01T00:02:00Z"),
+			testStatus("3", "2", "a", "2024-01-01T00:03:00Z"),
+		},
+		Descendants: []Status{
+			testStatus("5", "4", "b", "2024-01-01T00:05:00Z"),
+			testStatus("7", "4", "a", "2024-01-01T00:07:00Z"),
+			testStatus("6", "4", "a", "2024-01-01T00:06:00Z"),
+			testStatus("8", "6", "a", "2024-01-01T00:08:00Z"),
+			testStatus("9", "7", "a", "2024-01-01T00:09:00Z"),
+		},
+	}
+	focus := testStatus("4", "3", "a", "2024-01-01T00:04:00Z")
+
+	// The chain starts at the author's first status below another author
+	// and follows the earliest reply by the author.
+	got := statusIDs(NewThread(focus, ctx).SelfThread())
+	if want := []string{"2", "3", "4", "6", "8"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	thread := NewThread(testStatus("2", "1", "a", ""), Context{})
+	if got := thread.SelfThread(); len(got) != 1 || got[0].ID != "2" {
+		t.Errorf("got %v, want the focused status only", statusIDs(got))
+	}
+}
+
+func TestNewThreadCycle(t *testing.T) {
+	thread := NewThread(Status{ID: "2", InReplyToID: "1"}, Context{
+		Ancestors: []Status{{ID: "1", InReplyToID: "2"}},
+	})
+	if thread.Root.ID != "2" || thread.Root.Parent != nil || len(thread.Detached) != 0 {
+		t.Errorf("got root %s with %d detached roots, want 2 and none", thread.Root.ID, len(thread.Detached))
+	}
+	if got, want := nodeIDs(thread.Root.Flatten()), []string{"2", "1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	self := NewThread(Status{ID: "1", InReplyToID: "1"}, Context{})
+	if self.Root.ID != "1" || len(self.Root.Children) != 0 {
+		t.Errorf("got root %s with %d replies, want 1 without replies", self.Root.ID, len(self.Root.Children))
+	}
+}
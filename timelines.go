@@ -10,21 +10,115 @@ type Timelines struct {
 	api *API
 }
 
-// Home returns an array of statuses, most recent ones first.
-func (timelines Timelines) Home() ([]Status, error) {
+// TimelineOptions holds the params to filter the public timeline. Empty
+// values use the server's defaults.
+type TimelineOptions struct {
+	Pagination
+	Local     bool // Only return statuses of local accounts
+	Remote    bool // Only return statuses of remote accounts
+	OnlyMedia bool // Only return statuses with media attachments
+}
+
+func (opts TimelineOptions) values() url.Values {
+	v := url.Values{}
+	opts.Pagination.setValues(v)
+	if opts.Local {
+		v.Set("local", "true")
+	}
+	if opts.Remote {
+		v.Set("remote", "true")
+	}
+	if opts.OnlyMedia {
+		v.Set("only_media", "true")
+	}
+	return v
+}
+
+// HashtagOptions holds the params to filter a hashtag timeline. Empty values
+// use the server's defaults.
+type HashtagOptions struct {
+	TimelineOptions
+	Any  []string // Also return statuses with any of these tags
+	All  []string // Only return statuses that also have all of these tags
+	None []string // Skip statuses with any of these tags
+}
+
+func (opts HashtagOptions) values() url.Values {
+	v := opts.TimelineOptions.values()
+	for _, tag := range opts.Any {
+		v.Add("any[]", tag)
+	}
+	for _, tag := range opts.All {
+		v.Add("all[]", tag)
+	}
+	for _, tag := range opts.None {
+		v.Add("none[]", tag)
+	}
+	return v
+}
+
+// Home returns an array of statuses, most recent ones first. p may be nil.
+func (timelines Timelines) Home(p *Pagination) ([]Status, error) {
 	s := []Status{}
-	return s, timelines.api.Get("timelines/home", nil, &s)
+	return s, timelines.api.Get("timelines/home", paginationValues(p), &s)
 }
 
-// Public returns an array of statuses, most recent ones first.
-func (timelines Timelines) Public(v url.Values) ([]Status, error) {
+// Public returns an array of statuses, most recent ones first. opts may be
+// nil.
+func (timelines Timelines) Public(opts *TimelineOptions) ([]Status, error) {
 	s := []Status{}
+	v := url.Values{}
+	if opts != nil {
+		v = opts.values()
+	}
 	return s, timelines.api.Get("timelines/public", v, &s)
 }
 
-// Hashtag returns an array of statuses, most recent ones first.
-func (timelines Timelines) Hashtag(hashtag string, v url.Values) ([]Status, error) {
+// Local returns an array of statuses of local accounts, most recent ones
+// first. p may be nil.
+func (timelines Timelines) Local(p *Pagination) ([]Status, error) {
+	opts := TimelineOptions{Local: true}
+	if p != nil {
+		opts.Pagination = *p
+	}
+	return timelines.Public(&opts)
+}
+
+// Remote returns an array of statuses of remote accounts, most recent ones
+// first. p may be nil.
+func (timelines Timelines) Remote(p *Pagination) ([]Status, error) {
+	opts := TimelineOptions{Remote: true}
+	if p != nil {
+		opts.Pagination = *p
+	}
+	return timelines.Public(&opts)
+}
+
+// Hashtag returns an array of statuses, most recent ones first. opts may be
+// nil.
+func (timelines Timelines) Hashtag(hashtag string, opts *HashtagOptions) ([]Status, error) {
 	s := []Status{}
-	end := fmt.Sprintf("timelines/tag/%s", hashtag)
+	end := fmt.Sprintf("timelines/tag/%s", url.PathEscape(hashtag))
+	v := url.Values{}
+	if opts != nil {
+		v = opts.values()
+	}
 	return s, timelines.api.Get(end, v, &s)
 }
+
+// Link returns an array of statuses sharing a trending link, most recent
+// ones first. p may be nil.
+func (timelines Timelines) Link(u string, p *Pagination) ([]Status, error) {
+	s := []Status{}
+	v := paginationValues(p)
+	v.Set("url", u)
+	return s, timelines.api.Get("timelines/link", v, &s)
+}
+
+// List returns an array of statuses of the accounts in a list, most recent
+// ones first. p may be nil.
+func (timelines Timelines) List(id string, p *Pagination) ([]Status, error) {
+	s := []Status{}
+	end := fmt.Sprintf("timelines/list/%s", id)
+	return s, timelines.api.Get(end, paginationValues(p), &s)
+}
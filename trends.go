@@ -0,0 +1,44 @@
+package mastodon
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// Trends implements methods under /trends.
+type Trends struct {
+	api *API
+}
+
+// Tags returns tags which are used more than usual, most trending first.
+// Zero values use the server's defaults.
+func (trends Trends) Tags(limit, offset int) ([]Tag, error) {
+	t := []Tag{}
+	return t, trends.api.Get("trends/tags", offsetValues(limit, offset), &t)
+}
+
+// Statuses returns statuses which have been interacted with more than
+// usual, most trending first. Zero values use the server's defaults.
+func (trends Trends) Statuses(limit, offset int) ([]Status, error) {
+	s := []Status{}
+	return s, trends.api.Get("trends/statuses", offsetValues(limit, offset), &s)
+}
+
+// Links returns links which have been shared more than usual, most
+// trending first. Zero values use the server's defaults.
+func (trends Trends) Links(limit, offset int) ([]TrendsLink, error) {
+	l := []TrendsLink{}
+	return l, trends.api.Get("trends/links", offsetValues(limit, offset), &l)
+}
+
+// offsetValues returns the params of endpoints paginated by offset.
+func offsetValues(limit, offset int) url.Values {
+	v := url.Values{}
+	if limit > 0 {
+		v.Set("limit", strconv.Itoa(limit))
+	}
+	if offset > 0 {
+		v.Set("offset", strconv.Itoa(offset))
+	}
+	return v
+}
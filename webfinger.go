@@ -0,0 +1,149 @@
+package mastodon
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Handle identifies an account by its username and domain.
+type Handle struct {
+	Username string
+	Domain   string // Empty for local accounts; includes the port if any
+}
+
+// ParseHandle parses a handle in one of the forms "user", "@user",
+// "user@domain", "@user@domain", "acct:user@domain", "https://domain/@user"
+// or "https://domain/users/user".
+func ParseHandle(s string) (Handle, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "https://") || strings.HasPrefix(s, "http://") {
+		return parseProfileURL(s)
+	}
+
+	if strings.HasPrefix(s, "acct:") {
+		s = strings.TrimPrefix(s, "acct:")
+	} else {
+		s = strings.TrimPrefix(s, "@")
+	}
+	parts := strings.Split(s, "@")
+	if len(parts) > 2 || parts[0] == "" || (len(parts) == 2 && parts[1] == "") {
+		return Handle{}, fmt.Errorf("invalid handle %q", s)
+	}
+	h := Handle{Username: parts[0]}
+	if len(parts) == 2 {
+		h.Domain = strings.ToLower(parts[1])
+	}
+	return h, nil
+}
+
+func parseProfileURL(s string) (Handle, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return Handle{}, fmt.Errorf("invalid profile URL %q: %v", s, err)
+	}
+	path := strings.Split(strings.Trim(u.Path, "/"), "/")
+	h := Handle{Domain: strings.ToLower(u.Host)}
+	switch {
+	case len(path) == 1 && strings.HasPrefix(path[0], "@"):
+		h.Username = strings.TrimPrefix(path[0], "@")
+	case len(path) == 2 && path[0] == "users":
+		h.Username = path[1]
+	}
+	if h.Username == "" || h.Domain == "" || strings.Contains(h.Username, "@") {
+		return Handle{}, fmt.Errorf("invalid profile URL %q", s)
+	}
+	return h, nil
+}
+
+// String returns the handle as "user@domain" or "user" for local accounts.
+func (h Handle) String() string {
+	if h.Domain == "" {
+		return h.Username
+	}
+	return h.Username + "@" + h.Domain
+}
+
+// WebFinger resolves handles using WebFinger. It does not require
+// authentication.
+type WebFinger struct {
+	Client *http.Client // http.DefaultClient if nil
+}
+
+// Resource holds informations about a WebFinger resource.
+type Resource struct {
+	Subject string   `json:"subject"` // The resolved URI, e.g. "acct:user@domain"
+	Aliases []string `json:"aliases"` // Other URIs of the resource
+	Links   []Link   `json:"links"`   // Links to related resources
+}
+
+// Link holds informations about a WebFinger link.
+type Link struct {
+	Rel      string `json:"rel"`      // The relation, e.g. "self"
+	Type     string `json:"type"`     // The media type of the linked resource
+	Href     string `json:"href"`     // The URL of the linked resource
+	Template string `json:"template"` // An URL template, e.g. for remote follows
+}
+
+// ActorURL returns the URL of the ActivityPub actor.
+func (res Resource) ActorURL() string {
+	for _, l := range res.Links {
+		if l.Rel == "self" && (l.Type == "application/activity+json" || strings.HasPrefix(l.Type, "application/ld+json")) {
+			return l.Href
+		}
+	}
+	return ""
+}
+
+// ProfileURL returns the URL of the profile page.
+func (res Resource) ProfileURL() string {
+	for _, l := range res.Links {
+		if l.Rel == "http://webfinger.net/rel/profile-page" {
+			return l.Href
+		}
+	}
+	return ""
+}
+
+// Resolve looks up a handle in any form ParseHandle accepts on its domain.
+func (wf WebFinger) Resolve(handle string) (Resource, error) {
+	res := Resource{}
+	h, err := ParseHandle(handle)
+	if err != nil {
+		return res, err
+	}
+	if h.Domain == "" {
+		return res, fmt.Errorf("could not resolve %s: handle has no domain", handle)
+	}
+
+	client := wf.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	u := url.URL{
+		Scheme:   "https",
+		Host:     h.Domain,
+		Path:     "/.well-known/webfinger",
+		RawQuery: url.Values{"resource": {"acct:" + h.String()}}.Encode(),
+	}
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return res, fmt.Errorf("could not create request to %s: %v", u.Host, err)
+	}
+	req.Header.Set("Accept", "application/jrd+json, application/json")
+
+	r, err := client.Do(req)
+	if err != nil {
+		return res, fmt.Errorf("could not resolve %s: %v", h, err)
+	}
+	defer r.Body.Close()
+	if r.StatusCode != http.StatusOK {
+		return res, fmt.Errorf("could not resolve %s: %s", h, r.Status)
+	}
+	if err := json.NewDecoder(r.Body).Decode(&res); err != nil {
+		return res, fmt.Errorf("could not decode %s: %v", h, err)
+	}
+	return res, nil
+}
@@ -0,0 +1,107 @@
+package mastodon
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestParseHandle(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Handle // Expected handle; an error is expected if empty
+	}{
+		{"bob", Handle{Username: "bob"}},
+		{"@bob", Handle{Username: "bob"}},
+		{"bob@Example.com", Handle{Username: "bob", Domain: "example.com"}},
+		{" @bob@example.com ", Handle{Username: "bob", Domain: "example.com"}},
+		{"acct:bob@example.com", Handle{Username: "bob", Domain: "example.com"}},
+		{"https://example.com/@bob", Handle{Username: "bob", Domain: "example.com"}},
+		{"https://Example.com/users/bob/", Handle{Username: "bob", Domain: "example.com"}},
+		{"https://example.com:8443/@bob", Handle{Username: "bob", Domain: "example.com:8443"}},
+		{"http://example.com/@bob", Handle{Username: "bob", Domain: "example.com"}},
+		{"", Handle{}},
+		{"@", Handle{}},
+		{"bob@", Handle{}},
+		{"@bob@example.com@other.com", Handle{}},
+		{"acct:@example.com", Handle{}},
+		{"https://example.com/", Handle{}},
+		{"https://example.com/about", Handle{}},
+		{"https://example.com/@bob@other.com", Handle{}},
+		{"https://example.com/users/bob/statuses/1", Handle{}},
+	}
+	for _, test := range tests {
+		got, err := ParseHandle(test.in)
+		if test.want == (Handle{}) {
+			if err == nil {
+				t.Errorf("ParseHandle(%q) = %v, want error", test.in, got)
+			}
+			continue
+		}
+		if err != nil || got != test.want {
+			t.Errorf("ParseHandle(%q) = %v, %v, want %v", test.in, got, err, test.want)
+		}
+	}
+}
+
+func TestHandleString(t *testing.T) {
+	if got := (Handle{Username: "bob"}).String(); got != "bob" {
+		t.Errorf("got %q, want bob", got)
+	}
+	if got := (Handle{Username: "bob", Domain: "example.com"}).String(); got != "bob@example.com" {
+		t.Errorf("got %q, want bob@example.com", got)
+	}
+}
+
+func TestResourceURLs(t *testing.T) {
+	res := Resource{Links: []Link{
+		{Rel: "http://webfinger.net/rel/profile-page", Type: "text/html", Href: "https://example.com/@bob"},
+		{Rel: "self", Type: "text/html", Href: "https://example.com/wrong"},
+		{Rel: "self", Type: `application/ld+json; profile="https://www.w3.org/ns/activitystreams"`, Href: "https://example.com/users/bob"},
+	}}
+	if got := res.ActorURL(); got != "https://example.com/users/bob" {
+		t.Errorf("ActorURL() = %q", got)
+	}
+	if got := res.ProfileURL(); got != "https://example.com/@bob" {
+		t.Errorf("ProfileURL() = %q", got)
+	}
+	if got := (Resource{}).ActorURL(); got != "" {
+		t.Errorf("ActorURL() of empty resource = %q, want empty", got)
+	}
+}
+
+func TestWebFingerResolve(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/.well-known/webfinger" {
+			http.NotFound(w, r)
+			return
+		}
+		resource := r.URL.Query().Get("resource")
+		if !strings.HasPrefix(resource, "acct:bob@") {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/jrd+json")
+		fmt.Fprintf(w, `{"subject": %q, "links": [{"rel": "self", "type": "application/activity+json", "href": "https://example.com/users/bob"}]}`, resource)
+	}))
+	defer srv.Close()
+	host := strings.TrimPrefix(srv.URL, "https://")
+	wf := WebFinger{Client: srv.Client()}
+
+	res, err := wf.Resolve("https://" + host + "/@bob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Subject != "acct:bob@"+host || res.ActorURL() != "https://example.com/users/bob" {
+		t.Errorf("got %+v", res)
+	}
+
+	if _, err := wf.Resolve("@alice@" + host); err == nil {
+		t.Error("got no error resolving an unknown account")
+	}
+	if _, err := wf.Resolve("bob"); err == nil {
+		t.Error("got no error resolving a handle without domain")
+	}
+}